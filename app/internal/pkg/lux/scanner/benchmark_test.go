@@ -0,0 +1,127 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify the implementation of the "scanner" package.
+package scanner_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kdeconinck/lens/internal/pkg/lux/scanner"
+	"github.com/kdeconinck/lens/internal/pkg/lux/token"
+	"github.com/kdeconinck/lens/internal/pkg/text"
+)
+
+// The example of a lux file from the header of the "scanner" package.
+const config = `version = 1.0
+
+extension: ".cs" {
+    tokens: {
+        access: [ "public", "private", "internal" ]
+        kind: [ "class", "interface", "enum" ]
+        name: alpha
+    }
+
+    rule: "The name of an 'interface' must start with an 'I'." {
+        match: [ access, "interface", name: interfaceName ]
+        interfaceName: {
+            starts_with: "I"
+        }
+        enabled: true
+        pass: [ "public interface IUserRepository" ]
+        fail: ["public interface UserRepository"]
+    }
+}
+`
+
+// A rule library of a few thousand lines, built from the example of a lux file.
+var library = strings.Repeat(config, 200)
+
+// BM: Measure the scanning of a lux file into tokens.
+func BenchmarkScanner_NextToken(b *testing.B) {
+	for name, content := range map[string]string{
+		"Config":  config,
+		"Library": library,
+	} {
+		b.Run(name, func(b *testing.B) {
+			input := &text.Input{Content: content}
+
+			b.ReportAllocs()
+			b.SetBytes(int64(len(content)))
+
+			for b.Loop() {
+				scanner := scanner.New(input)
+
+				for scanner.NextToken().Type != token.EOF {
+				}
+			}
+		})
+	}
+}
+
+// BM: Measure the scanning of a lux file into tokens with their trivia.
+func BenchmarkScanner_NextLossless(b *testing.B) {
+	input := &text.Input{Content: library}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(library)))
+
+	for b.Loop() {
+		scanner := scanner.New(input)
+
+		for scanner.NextLossless().Type != token.EOF {
+		}
+	}
+}
+
+// BM: Measure the scanning of a lux file that's read from a reader.
+func BenchmarkNewReader(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(library)))
+
+	for b.Loop() {
+		scanner := scanner.NewReader(strings.NewReader(library))
+
+		for scanner.NextToken().Type != token.EOF {
+		}
+	}
+}
+
+// BM: Measure the scanning of a lux file that's full of problems.
+func BenchmarkScanner_Diagnostics(b *testing.B) {
+	content := strings.Repeat("name: \"a\\qb\" 1__0 @$ \x00 \xFF\n", 200)
+	input := &text.Input{Content: content}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(content)))
+
+	for b.Loop() {
+		scanner := scanner.New(input)
+
+		for scanner.NextToken().Type != token.EOF {
+		}
+	}
+}
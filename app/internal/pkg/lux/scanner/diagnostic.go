@@ -0,0 +1,122 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package scanner implements a scanner for the lux language.
+package scanner
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kdeconinck/lens/internal/pkg/text"
+)
+
+// Severity represents how serious the problem reported by a [Diagnostic] is.
+type Severity int
+
+// The different severities of a diagnostic.
+const (
+	// Error is used for problems that make the input invalid.
+	Error Severity = iota
+
+	// Warning is used for problems that don't make the input invalid, but are likely mistakes.
+	Warning
+)
+
+// Maps a [Severity] to its human-readable name, which matches the level of a [text.Snippet].
+var severityMap = map[Severity]string{
+	Error:   "error",
+	Warning: "warning",
+}
+
+// String returns the string representation of the severity.
+func (s Severity) String() string {
+	value, ok := severityMap[s]
+
+	if ok {
+		return value
+	}
+
+	return "Unknown(" + strconv.Itoa(int(s)) + ")"
+}
+
+// Code identifies the kind of problem that a [Diagnostic] reports.
+//
+// Codes never change once they're assigned, so they can be used to look up documentation, to suppress a diagnostic or
+// to translate its message.
+type Code int
+
+// The different kinds of problems that the scanner reports.
+const (
+	// InvalidCharacter is used for a run of characters that can't start a token.
+	InvalidCharacter Code = 1
+
+	// InvalidEncoding is used for a run of bytes that aren't valid UTF-8.
+	InvalidEncoding Code = 2
+
+	// UnclosedString is used for a string literal that isn't terminated.
+	UnclosedString Code = 3
+
+	// InvalidEscape is used for a malformed escape sequence in a string literal.
+	InvalidEscape Code = 4
+
+	// UnterminatedComment is used for a block comment that isn't terminated.
+	UnterminatedComment Code = 5
+
+	// InvalidNumber is used for a malformed numeric literal.
+	InvalidNumber Code = 6
+
+	// ControlCharacter is used for a run of control characters, such as U+0000, that aren't whitespace.
+	ControlCharacter Code = 7
+)
+
+// String returns the string representation of the code, such as "LUX0001".
+func (c Code) String() string {
+	return fmt.Sprintf("LUX%04d", int(c))
+}
+
+// Diagnostic is a problem that the scanner found in its input.
+type Diagnostic struct {
+	// Code identifies the kind of problem.
+	Code Code
+
+	// Severity is how serious the problem is.
+	Severity Severity
+
+	// Span is the exact location of the problem in the source it was read from.
+	Span text.Span
+
+	// Pos is the position of the first byte of the problem in the [text.FileSet] of the file it was read from, or
+	// [text.NoPos] if the source isn't part of a file set.
+	Pos text.Pos
+
+	// Message is a human-readable description of the problem.
+	Message string
+}
+
+// String returns the string representation of the diagnostic.
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("[%s] %s %s: %s", d.Span, d.Severity, d.Code, d.Message)
+}
@@ -0,0 +1,119 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify the implementation of the "scanner" package.
+package scanner_test
+
+import (
+	"testing"
+
+	"github.com/kdeconinck/lens/internal/pkg/assert"
+	"github.com/kdeconinck/lens/internal/pkg/lux/scanner"
+)
+
+// UT: Get the human-readable representation of a severity.
+func Test_SeverityString(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		severityInput scanner.Severity
+		want          string
+	}{
+		"When formatting the 'Error' severity, it's displayed as 'error'.": {
+			severityInput: scanner.Error,
+			want:          "error",
+		},
+		"When formatting the 'Warning' severity, it's displayed as 'warning'.": {
+			severityInput: scanner.Warning,
+			want:          "warning",
+		},
+		"When formatting an unknown severity, it's displayed as 'Unknown(N)'.": {
+			severityInput: scanner.Severity(99),
+			want:          "Unknown(99)",
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := tc.severityInput.String()
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Get the human-readable representation of a code.
+func Test_CodeString(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		codeInput scanner.Code
+		want      string
+	}{
+		"When formatting a code, it's displayed as 'LUX' followed by 4 digits.": {
+			codeInput: scanner.InvalidCharacter,
+			want:      "LUX0001",
+		},
+		"When formatting a code with more than 4 digits, none of them is dropped.": {
+			codeInput: scanner.Code(12345),
+			want:      "LUX12345",
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := tc.codeInput.String()
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Get the human-readable representation of a diagnostic.
+func Test_DiagnosticString(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	diagnostic := newDiagnostic(scanner.UnclosedString, 0, 6, "Unclosed string literal.")
+
+	// Act.
+	got, want := diagnostic.String(), "[0..6] error LUX0003: Unclosed string literal."
+
+	// Assert.
+	assert.Equalf(t, got, want, "\n\n"+
+		"UT Name:  When formatting a 'Diagnostic' it's displayed as '[Span] severity CODE: Message'.\n"+
+		"\033[32mExpected: %s\033[0m\n"+
+		"\033[31mActual:   %s\033[0m\n\n", want, got)
+}
@@ -0,0 +1,163 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package scanner implements a scanner for the lux language.
+package scanner
+
+import (
+	"iter"
+
+	"github.com/kdeconinck/lens/internal/pkg/lux/token"
+)
+
+// Mark is a position in the stream of tokens returned by [Scanner.NextToken], see [Scanner.Mark].
+type Mark int
+
+// All returns an iterator over the tokens that remain in the input, as returned by [Scanner.NextToken].
+//
+// The iteration stops at the "EOF" token, which isn't yielded.
+func (scanner *Scanner) All() iter.Seq[token.Token] {
+	return func(yield func(token.Token) bool) {
+		for tok := scanner.NextToken(); tok.Type != token.EOF; tok = scanner.NextToken() {
+			if !yield(tok) {
+				return
+			}
+		}
+	}
+}
+
+// Peek returns the token that comes n tokens after the one that's returned by the next call to [Scanner.NextToken],
+// without consuming any of them. Peek(0) returns the next token itself.
+//
+// The tokens that are scanned to look ahead are kept in a ring buffer until they're consumed, so every token is only
+// scanned once. Peeking past the end of the input returns the "EOF" token. Peek panics if n is negative.
+func (scanner *Scanner) Peek(n int) token.Token {
+	if n < 0 {
+		panic("scanner: negative lookahead")
+	}
+
+	for scanner.lookahead.end() <= scanner.cursor+n {
+		scanner.lookahead.push(scanner.scan())
+	}
+
+	return scanner.lookahead.at(scanner.cursor + n)
+}
+
+// Mark returns the current position in the stream of tokens, which can be returned to with [Scanner.Reset].
+//
+// Every token that's consumed while a mark is active is kept in the lookahead buffer, so returning to a mark doesn't
+// scan the input again, nor does it report its diagnostics again. Marks can be nested, and a mark stays active until
+// it's passed to [Scanner.Reset] or [Scanner.Release].
+func (scanner *Scanner) Mark() Mark {
+	m := Mark(scanner.cursor)
+	scanner.marks = append(scanner.marks, m)
+
+	return m
+}
+
+// Reset returns to the position of the active mark m, so that [Scanner.NextToken] returns the tokens that follow it
+// again.
+//
+// Both m and the marks that were created after it are released. Reset panics if m isn't active.
+func (scanner *Scanner) Reset(m Mark) {
+	scanner.Release(m)
+	scanner.cursor = int(m)
+}
+
+// Release releases the active mark m, and the marks that were created after it, without changing the position.
+//
+// Release panics if m isn't active.
+func (scanner *Scanner) Release(m Mark) {
+	scanner.marks = scanner.marks[:scanner.markIndex(m)]
+	scanner.discard()
+}
+
+// Returns the index of the active mark m, which is the most recent one if m was marked more than once.
+func (scanner *Scanner) markIndex(m Mark) int {
+	for idx := len(scanner.marks) - 1; idx >= 0; idx-- {
+		if scanner.marks[idx] == m {
+			return idx
+		}
+	}
+
+	panic("scanner: mark isn't active")
+}
+
+// Drop the tokens from the lookahead buffer that can't be returned anymore.
+func (scanner *Scanner) discard() {
+	oldest := scanner.cursor
+
+	if len(scanner.marks) > 0 {
+		oldest = int(scanner.marks[0])
+	}
+
+	scanner.lookahead.discard(oldest)
+}
+
+// A ring buffer of tokens that grows when it's full.
+//
+// Tokens are addressed by their index in the stream of tokens, and the buffer holds the ones from first up to end().
+type ring struct {
+	tokens []token.Token
+	first  int
+	n      int
+}
+
+// Returns the index of the token after the last one in the buffer.
+func (r *ring) end() int {
+	return r.first + r.n
+}
+
+// Returns the token with the given index, which must be in the buffer.
+func (r *ring) at(idx int) token.Token {
+	return r.tokens[idx&(len(r.tokens)-1)]
+}
+
+// Append tok to the end of the buffer.
+func (r *ring) push(tok token.Token) {
+	if r.n == len(r.tokens) {
+		r.grow()
+	}
+
+	r.tokens[r.end()&(len(r.tokens)-1)] = tok
+	r.n++
+}
+
+// Drop the tokens that come before the given index from the buffer.
+func (r *ring) discard(idx int) {
+	r.n -= idx - r.first
+	r.first = idx
+}
+
+// Double the capacity of the buffer. The capacity is always a power of 2, so an index is wrapped with a bit mask.
+func (r *ring) grow() {
+	tokens := make([]token.Token, max(8, 2*len(r.tokens)))
+
+	for idx := r.first; idx < r.end(); idx++ {
+		tokens[idx&(len(tokens)-1)] = r.at(idx)
+	}
+
+	r.tokens = tokens
+}
@@ -0,0 +1,242 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify the implementation of the "scanner" package.
+package scanner_test
+
+import (
+	"testing"
+
+	"github.com/kdeconinck/lens/internal/pkg/assert"
+	"github.com/kdeconinck/lens/internal/pkg/lux/token"
+)
+
+// UT: Range over the tokens of an input.
+func TestScanner_All(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When ranging over the tokens, every token up to EOF is yielded.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("name: \"lens\" // Comment.")
+		wantTokens := newTokenSet(
+			newValueToken(token.Ident, "name", 0, 4),
+			newToken(token.Colon, 4, 5),
+			newValueToken(token.String, "lens", 6, 12),
+		)
+
+		// Act.
+		var got []token.Token
+
+		for tok := range scanner.All() {
+			got = append(got, tok)
+		}
+
+		// Assert.
+		assert.Equalf(t, len(got), len(wantTokens), "\n\n"+
+			"UT Name:  When ranging over the tokens, every token up to EOF is yielded.\n"+
+			"\033[32mExpected: %v\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", wantTokens, got)
+
+		for idx, want := range wantTokens {
+			assert.Equalf(t, got[idx], want, "\n\n"+
+				"UT Name:  When ranging over the tokens, every token up to EOF is yielded.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got[idx])
+		}
+	})
+
+	t.Run("When breaking out of the loop, the remaining tokens can still be scanned.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("a b c")
+
+		// Act.
+		for tok := range scanner.All() {
+			if tok.Literal == "b" {
+				break
+			}
+		}
+
+		got, want := scanner.NextToken(), newValueToken(token.Ident, "c", 4, 5)
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When breaking out of the loop, the remaining tokens can still be scanned.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, got)
+	})
+}
+
+// UT: Look ahead at the tokens of an input without consuming them.
+func TestScanner_Peek(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		nInput int
+		want   token.Token
+	}{
+		"When peeking 0 tokens ahead, the next token is returned.": {
+			nInput: 0,
+			want:   newValueToken(token.Ident, "a", 0, 1),
+		},
+		"When peeking multiple tokens ahead, the token at that distance is returned.": {
+			nInput: 2,
+			want:   newToken(token.LBrace, 4, 5),
+		},
+		"When peeking past the end of the input, the EOF token is returned.": {
+			nInput: 10,
+			want:   newToken(token.EOF, 6, 6),
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			scanner := newScanner("a = {}")
+
+			// Act.
+			got := scanner.Peek(tc.nInput)
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.want, got)
+
+			// Act.
+			got, want := scanner.NextToken(), newValueToken(token.Ident, "a", 0, 1)
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  %s (The peeked tokens aren't consumed)\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, want, got)
+		})
+	}
+}
+
+// UT: Return to an earlier position in the tokens of an input.
+func TestScanner_Reset(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When resetting to a mark, the tokens after it are returned again, but not scanned again.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("a @ b c d e f g h i j")
+		scanner.NextToken()
+
+		// Act.
+		m := scanner.Mark()
+
+		for range 9 {
+			scanner.NextToken()
+		}
+
+		scanner.Reset(m)
+
+		got, want := scanner.NextToken(), newValueToken(token.Ident, "b", 4, 5)
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When resetting to a mark, the tokens after it are returned again, but not scanned again.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, got)
+
+		assert.Equalf(t, len(scanner.Diagnostics()), 1, "\n\n"+
+			"UT Name:  When resetting to a mark, the tokens after it are returned again, but not scanned again.\n"+
+			"\033[32mExpected: 1 diagnostic\033[0m\n"+
+			"\033[31mActual:   %d diagnostics\033[0m\n\n", len(scanner.Diagnostics()))
+	})
+
+	t.Run("When resetting to an outer mark, the inner marks are released.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("a b c")
+
+		// Act.
+		outer := scanner.Mark()
+		scanner.NextToken()
+		scanner.Mark()
+		scanner.NextToken()
+		scanner.Reset(outer)
+
+		got, want := scanner.NextToken(), newValueToken(token.Ident, "a", 0, 1)
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When resetting to an outer mark, the inner marks are released.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, got)
+	})
+
+	t.Run("When resetting to an inner mark, the outer mark stays active.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("a b c")
+
+		// Act.
+		outer := scanner.Mark()
+		scanner.NextToken()
+		inner := scanner.Mark()
+		scanner.NextToken()
+		scanner.Reset(inner)
+		scanner.NextToken()
+		scanner.Reset(outer)
+
+		got, want := scanner.NextToken(), newValueToken(token.Ident, "a", 0, 1)
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When resetting to an inner mark, the outer mark stays active.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, got)
+	})
+
+	t.Run("When releasing a mark, the position is unchanged.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("a b c")
+
+		// Act.
+		m := scanner.Mark()
+		scanner.NextToken()
+		scanner.Release(m)
+
+		got, want := scanner.NextToken(), newValueToken(token.Ident, "b", 2, 3)
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When releasing a mark, the position is unchanged.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, got)
+	})
+}
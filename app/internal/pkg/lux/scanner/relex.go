@@ -0,0 +1,123 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package scanner implements a scanner for the lux language.
+package scanner
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/kdeconinck/lens/internal/pkg/lux/token"
+	"github.com/kdeconinck/lens/internal/pkg/text"
+)
+
+// Relex returns the tokens and the diagnostics of input, which is the result of applying edit to the input that the old
+// tokens and diagnostics were scanned from.
+//
+// The old tokens must be the ones returned by [Scanner.NextToken], up to and including the "EOF" token, and the old
+// diagnostics the ones returned by [Scanner.Diagnostics] after that. Only the tokens that are damaged by the edit are
+// scanned again: scanning starts after the last token that ends before the edit, and stops as soon as a token after the
+// edit starts where one of the old tokens starts. The diagnostics of the region that's scanned again are replaced by
+// the new ones. The remaining old tokens and diagnostics are reused, with their spans moved by the difference in
+// length. The old slices aren't modified.
+//
+// A [text.File] can't change, so Relex is meant for tokens scanned by a [Scanner] created by [New], which don't carry a
+// position in a [text.FileSet].
+func Relex(input *text.Input, old []token.Token, diags []Diagnostic, edit text.TextEdit) ([]token.Token, []Diagnostic) {
+	first, _ := slices.BinarySearchFunc(old, edit.Span.Start, compareEnd)
+
+	scanner := New(input)
+	tokens := slices.Clone(old[:first])
+
+	if first > 0 {
+		scanner.pos = old[first-1].Span.End
+	}
+
+	restart := scanner.pos
+
+	// Beyond the new text, the input is the same as before, so the tokens are the same once they start at the same
+	// place. From there on, the old tokens and diagnostics are reused, and the ones that are scanned again are dropped.
+	newEnd := edit.Span.Start + len(edit.NewText)
+	delta := len(edit.NewText) - edit.Span.Len()
+	reused, reusedFrom := len(old), math.MaxInt
+
+	for {
+		tok := scanner.scan()
+
+		if tok.Span.Start >= newEnd {
+			if idx, ok := slices.BinarySearchFunc(old, tok.Span.Start-delta, compareStart); ok {
+				reused, reusedFrom = idx, old[idx].Span.Start
+
+				break
+			}
+		}
+
+		tokens = append(tokens, tok)
+
+		if tok.Type == token.EOF {
+			break
+		}
+	}
+
+	for _, tok := range old[reused:] {
+		tok.Span = tok.Span.Shift(edit.Span, len(edit.NewText))
+		tokens = append(tokens, tok)
+	}
+
+	var result []Diagnostic
+
+	for _, d := range diags {
+		if d.Span.Start < restart {
+			result = append(result, d)
+		}
+	}
+
+	for _, d := range scanner.Diagnostics() {
+		if d.Span.Start-delta < reusedFrom {
+			result = append(result, d)
+		}
+	}
+
+	for _, d := range diags {
+		if d.Span.Start >= reusedFrom {
+			d.Span = d.Span.Shift(edit.Span, len(edit.NewText))
+			result = append(result, d)
+		}
+	}
+
+	return tokens, result
+}
+
+// Returns how the end of the span of tok compares to offset.
+func compareEnd(tok token.Token, offset int) int {
+	return cmp.Compare(tok.Span.End, offset)
+}
+
+// Returns how the start of the span of tok compares to offset.
+func compareStart(tok token.Token, offset int) int {
+	return cmp.Compare(tok.Span.Start, offset)
+}
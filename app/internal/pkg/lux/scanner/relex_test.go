@@ -0,0 +1,194 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify the implementation of the "scanner" package.
+package scanner_test
+
+import (
+	"testing"
+
+	"github.com/kdeconinck/lens/internal/pkg/assert"
+	"github.com/kdeconinck/lens/internal/pkg/lux/scanner"
+	"github.com/kdeconinck/lens/internal/pkg/lux/token"
+	"github.com/kdeconinck/lens/internal/pkg/text"
+)
+
+// UT: Update the tokens of an input after an edit.
+func TestRelex(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		contentInput string
+		spanInput    text.Span
+		newTextInput string
+	}{
+		"When a value is replaced, the tokens are the same as when scanning the new input.": {
+			contentInput: "version = 1.0\nname: \"lens\"",
+			spanInput:    newSpan(10, 13),
+			newTextInput: "2.0.1",
+		},
+		"When text is typed at the end of a token, the token grows.": {
+			contentInput: "name: lens\nenabled: true",
+			spanInput:    newSpan(10, 10),
+			newTextInput: "_v2",
+		},
+		"When text is inserted in front of the first token, it's scanned.": {
+			contentInput: "name: lens",
+			spanInput:    newSpan(0, 0),
+			newTextInput: "[ ",
+		},
+		"When text is appended to the input, it's scanned.": {
+			contentInput: "name: lens",
+			spanInput:    newSpan(10, 10),
+			newTextInput: "\nenabled: true",
+		},
+		"When a sign is followed by a new digit, the sign becomes part of the number.": {
+			contentInput: "a +b c",
+			spanInput:    newSpan(3, 4),
+			newTextInput: "1",
+		},
+		"When a quote is inserted, the rest of the line becomes a string.": {
+			contentInput: "match: [ access, name ]\nenabled: true",
+			spanInput:    newSpan(9, 9),
+			newTextInput: "\"",
+		},
+		"When a quote turns an empty string into a multi-line string, the rest of the input is part of it.": {
+			contentInput: "a: \"\" b\nc: \"d\"",
+			spanInput:    newSpan(5, 5),
+			newTextInput: "\"",
+		},
+		"When a block comment is opened, the rest of the input is part of it.": {
+			contentInput: "a: b\nc: { d: e }",
+			spanInput:    newSpan(5, 5),
+			newTextInput: "/*",
+		},
+		"When a block comment is closed, the tokens after it are scanned.": {
+			contentInput: "a: b /* c: d\ne: f",
+			spanInput:    newSpan(12, 12),
+			newTextInput: " */",
+		},
+		"When text is inserted in front of a problem, its diagnostic moves along.": {
+			contentInput: "a: b\nc: @ d",
+			spanInput:    newSpan(0, 0),
+			newTextInput: "x_",
+		},
+		"When a problem is fixed, its diagnostic is dropped.": {
+			contentInput: "a: 1__0 $\nb: c",
+			spanInput:    newSpan(3, 7),
+			newTextInput: "10",
+		},
+		"When a problem is introduced, it's reported.": {
+			contentInput: "a: 10\nb: \"c\"\nd: @",
+			spanInput:    newSpan(6, 6),
+			newTextInput: "@ ",
+		},
+		"When the whole input is deleted, only EOF remains.": {
+			contentInput: "a: b\nc: d",
+			spanInput:    newSpan(0, 9),
+			newTextInput: "",
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			content := tc.contentInput[:tc.spanInput.Start] + tc.newTextInput + tc.contentInput[tc.spanInput.End:]
+			oldTokens, oldDiagnostics := scanAll(tc.contentInput)
+			wantTokens, wantDiagnostics := scanAll(content)
+			edit := text.TextEdit{Span: tc.spanInput, NewText: tc.newTextInput}
+
+			// Act.
+			got, gotDiagnostics := scanner.Relex(&text.Input{Content: content}, oldTokens, oldDiagnostics, edit)
+
+			// Assert.
+			assert.Equalf(t, len(got), len(wantTokens), "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, wantTokens, got)
+
+			for idx, want := range wantTokens[:min(len(got), len(wantTokens))] {
+				assert.Equalf(t, got[idx], want, "\n\n"+
+					"UT Name:  %s\n"+
+					"\033[32mExpected: #%d - %s\033[0m\n"+
+					"\033[31mActual:   #%d - %s\033[0m\n\n", tcName, idx, want, idx, got[idx])
+			}
+
+			assert.Equalf(t, len(gotDiagnostics), len(wantDiagnostics), "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, wantDiagnostics, gotDiagnostics)
+
+			for idx, want := range wantDiagnostics[:min(len(gotDiagnostics), len(wantDiagnostics))] {
+				assert.Equalf(t, gotDiagnostics[idx], want, "\n\n"+
+					"UT Name:  %s\n"+
+					"\033[32mExpected: #%d - %s\033[0m\n"+
+					"\033[31mActual:   #%d - %s\033[0m\n\n", tcName, idx, want, idx, gotDiagnostics[idx])
+			}
+		})
+	}
+
+	t.Run("When the tokens resynchronise after the edit, the old tokens that follow are reused.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		oldTokens, oldDiagnostics := scanAll("a: b\nc: d\ne: @ f")
+		oldTokens[len(oldTokens)-2].Literal = "reused"
+		oldDiagnostics[0].Message = "Reused."
+		edit := text.TextEdit{Span: newSpan(4, 4), NewText: "cd"}
+
+		// Act.
+		got, gotDiagnostics := scanner.Relex(&text.Input{Content: "a: bcd\nc: d\ne: @ f"}, oldTokens, oldDiagnostics, edit)
+
+		want := newValueToken(token.Ident, "reused", 17, 18)
+		wantDiagnostic := newDiagnostic(scanner.InvalidCharacter, 15, 16, "Reused.")
+
+		// Assert.
+		assert.Equalf(t, got[len(got)-2], want, "\n\n"+
+			"UT Name:  When the tokens resynchronise after the edit, the old tokens that follow are reused.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, got[len(got)-2])
+
+		assert.Equalf(t, gotDiagnostics[0], wantDiagnostic, "\n\n"+
+			"UT Name:  When the tokens resynchronise after the edit, the old diagnostics that follow are reused.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", wantDiagnostic, gotDiagnostics[0])
+	})
+}
+
+// Returns the tokens of content, up to and including the EOF token, and the diagnostics that are reported.
+func scanAll(content string) ([]token.Token, []scanner.Diagnostic) {
+	scanner := newScanner(content)
+
+	var tokens []token.Token
+
+	for {
+		tok := scanner.NextToken()
+		tokens = append(tokens, tok)
+
+		if tok.Type == token.EOF {
+			return tokens, scanner.Diagnostics()
+		}
+	}
+}
@@ -50,7 +50,11 @@
 package scanner
 
 import (
-	"fmt"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
 	"unicode"
 	"unicode/utf8"
 
@@ -58,29 +62,195 @@ import (
 	"github.com/kdeconinck/lens/internal/pkg/text"
 )
 
-// Scanner transforms a [text.Input] into a stream of [token.Token]s.
+// Maps the character after a backslash to the character that the escape sequence represents.
+var escapeMap = map[rune]rune{
+	'"':  '"',
+	'\\': '\\',
+	'n':  '\n',
+	't':  '\t',
+	'r':  '\r',
+}
+
+// Maps the letter of a number prefix ("0x", "0o" or "0b") to the base that it selects.
+var numberBases = map[byte]int{
+	'x': 16,
+	'o': 8,
+	'b': 2,
+}
+
+// The classes that an ASCII character belongs to, as bit flags.
+const (
+	classSpace uint8 = 1 << iota
+	classDigit
+	classWord
+	classPunct
+	classControl
+)
+
+// Maps every ASCII character to the classes that it belongs to, so that the common case doesn't need the [unicode]
+// package.
+var asciiClass = func() [utf8.RuneSelf]uint8 {
+	var table [utf8.RuneSelf]uint8
+
+	for c := range rune(utf8.RuneSelf) {
+		switch {
+		case unicode.IsSpace(c):
+			table[c] = classSpace
+
+		case unicode.IsControl(c):
+			table[c] = classControl
+
+		case isDecimal(c):
+			table[c] = classDigit | classWord
+
+		case unicode.IsLetter(c) || c == '_':
+			table[c] = classWord
+
+		case strings.ContainsRune(".{}[]:=,\"", c):
+			table[c] = classPunct
+		}
+	}
+
+	return table
+}()
+
+// The character that's returned when looking past the end of the input. Unlike 0, it can't occur in the input.
+const eof rune = -1
+
+// DefaultChunkSize is the number of bytes that a [Scanner] created by [NewReader] reads at once.
+const DefaultChunkSize = 4096
+
+// The number of reads in a row that return no data and no error after which a reader is considered broken, like in the
+// "bufio" package.
+const maxEmptyReads = 100
+
+// Scanner transforms a [text.Input], or the data of an [io.Reader], into a stream of [token.Token]s.
 type Scanner struct {
-	input      *text.Input
-	tokenStart int
-	pos        int
+	// The data that's scanned, or the part of it that's buffered when the data is read from a reader. All offsets,
+	// including the spans of the tokens, are relative to the start of the data, and src starts at offset base.
+	src  string
+	base int
+
+	// When the data is read from a reader, buf holds src, and keep is the offset of the first byte that must stay
+	// buffered, which is the start of the token or trivia that's being scanned.
+	buf  strings.Builder
+	keep int
+
+	// When mapping is set, the segments of the value of the string literal that's being scanned are collected, see
+	// [Segments].
+	mapping  bool
+	segments []Segment
+
+	reader      io.Reader
+	chunk       []byte
+	emptyReads  int
+	err         error
+	file        *text.File
+	tokenStart  int
+	pos         int
+	diagnostics []Diagnostic
+	lookahead   ring
+	cursor      int
+	marks       []Mark
 }
 
 // New initializes a [Scanner] with the provided input.
 func New(input *text.Input) *Scanner {
 	return &Scanner{
-		input: input,
+		src: input.Content,
+	}
+}
+
+// NewFile initializes a [Scanner] with the input of the provided file.
+//
+// The tokens that are scanned carry their position in the [text.FileSet] of the file in [token.Token.Pos].
+func NewFile(file *text.File) *Scanner {
+	return &Scanner{
+		src:  file.Input().Content,
+		file: file,
+	}
+}
+
+// NewReader initializes a [Scanner] that reads its input from r, in chunks of [DefaultChunkSize] bytes.
+//
+// Only the data from the start of the token (or comment) that's being scanned onwards is buffered, so the memory that's
+// used is bounded by the chunk size and the length of the longest token, rather than by the length of the input. Tokens
+// never break at the boundary of a chunk, their spans are offsets from the start of the data that's read from r, and
+// their literals don't refer to the buffer.
+//
+// An error returned by r ends the input, and is reported by [Scanner.Err]. So does a reader that keeps returning no
+// data, which is reported as [io.ErrNoProgress].
+func NewReader(r io.Reader) *Scanner {
+	return NewReaderSize(r, DefaultChunkSize)
+}
+
+// NewReaderSize is like [NewReader], but reads chunks of the given size, which is at least 1 byte.
+func NewReaderSize(r io.Reader, size int) *Scanner {
+	return &Scanner{
+		reader: r,
+		chunk:  make([]byte, max(size, 1)),
 	}
 }
 
 // NextToken scans the next token from the input.
 // It skips whitespace and comments automatically.
+//
+// Problems in the input are reported as a [Diagnostic] instead of interrupting the stream of tokens: characters that
+// can't start a token are skipped, and malformed literals still result in a token of the type they resemble.
+//
+// Tokens that were already scanned by [Scanner.Peek], or that are scanned again after [Scanner.Reset], are taken from
+// the lookahead buffer instead of being scanned again.
 func (scanner *Scanner) NextToken() token.Token {
-	scanner.skipWhitespace()
-	scanner.tokenStart = scanner.pos
+	tok := scanner.Peek(0)
+	scanner.cursor++
+	scanner.discard()
+
+	return tok
+}
+
+// NextLossless scans the next token from the input, together with the whitespace and comments around it.
+//
+// Every byte of the input ends up in exactly one token or piece of trivia. Characters that can't start a token are
+// kept as "Skipped" trivia, and reported as a [Diagnostic] like they are by [Scanner.NextToken].
+//
+// NextLossless doesn't use the lookahead buffer, so it can't be mixed with [Scanner.NextToken] or [Scanner.Peek].
+func (scanner *Scanner) NextLossless() token.Lossless {
+	var tok token.Lossless
+
+	tok.Leading = scanner.collectTrivia(false)
+	scanner.tokenStart, scanner.keep = scanner.pos, scanner.pos
+	tok.Token = scanner.scanToken()
+
+	if tok.Type != token.EOF {
+		tok.Trailing = scanner.collectTrivia(true)
+	}
+
+	return tok
+}
 
+// Err returns the first error, other than [io.EOF], that was returned by the reader of the scanner, if any.
+func (scanner *Scanner) Err() error {
+	return scanner.err
+}
+
+// Diagnostics returns the problems that were found in the input so far, in the order in which they were found.
+func (scanner *Scanner) Diagnostics() []Diagnostic {
+	return scanner.diagnostics
+}
+
+// Scan the next token from the input, skipping the whitespace and comments in front of it.
+func (scanner *Scanner) scan() token.Token {
+	scanner.skipTrivia()
+	scanner.tokenStart, scanner.keep = scanner.pos, scanner.pos
+
+	return scanner.scanToken()
+}
+
+// Scan the token that starts at the current position.
+func (scanner *Scanner) scanToken() token.Token {
 	r := scanner.peek()
 
-	if r == 0 {
+	if r == eof {
 		return scanner.emit(token.EOF, "")
 	}
 
@@ -126,22 +296,49 @@ func (scanner *Scanner) NextToken() token.Token {
 		return scanner.emit(token.Comma, "")
 
 	case '"':
+		return scanner.scanStringLiteral(false)
+	}
+
+	if scanner.lookingAt(`r"`) {
 		scanner.consume()
 
-		return scanner.scanString()
+		return scanner.scanStringLiteral(true)
 	}
 
-	if unicode.IsDigit(r) {
+	if isDigit(r) || ((r == '+' || r == '-') && isDecimal(scanner.peekNext())) {
 		return scanner.scanNumber()
 	}
 
-	if unicode.IsLetter(r) || r == '_' {
-		return scanner.scanIdentifier()
+	// Any other character that can start a token starts an identifier, see [Scanner.startsToken].
+	return scanner.scanIdentifier()
+}
+
+// Reports whether r, the character at the current position, starts a token.
+func (scanner *Scanner) startsToken(r rune) bool {
+	if r == '+' || r == '-' {
+		return isDecimal(scanner.peekNext())
+	}
+
+	if uint32(r) < utf8.RuneSelf {
+		return asciiClass[r]&(classPunct|classWord) != 0
+	}
+
+	return isWordChar(r)
+}
+
+// Scan the string literal that starts at the current position, which is either a single-line string ("...") or a
+// multi-line string ("""...""").
+// Escape sequences are only recognised if the string isn't raw; the "r" prefix of a raw string is already consumed.
+func (scanner *Scanner) scanStringLiteral(raw bool) token.Token {
+	if scanner.lookingAt(`"""`) {
+		scanner.pos += 3
+
+		return scanner.scanMultilineString(raw)
 	}
 
 	scanner.consume()
 
-	return scanner.emit(token.Error, fmt.Sprintf("Invalid character '%s'.", string(r)))
+	return scanner.scanString(raw)
 }
 
 // Keep reading data until the termination of the string.
@@ -149,43 +346,433 @@ func (scanner *Scanner) NextToken() token.Token {
 // - We hit the string termination character (quote).
 // - We hit EOF (this means an error, beacused the string isn't properly terminated).
 // - We hit a newline (this means an error, beacused the string isn't properly terminated).
-func (scanner *Scanner) scanString() token.Token {
+//
+// Unless the string is raw, escape sequences are decoded in the value of the string, while the span of the token covers
+// the raw text. Bytes that aren't valid UTF-8 and control characters are kept in the value of the string. A diagnostic
+// is reported for every malformed escape sequence, for every run of invalid bytes and for every run of control
+// characters. An unterminated string ends at the end of the line.
+func (scanner *Scanner) scanString(raw bool) token.Token {
+	if tok, ok := scanner.scanPlainString(raw); ok {
+		return tok
+	}
+
+	var value strings.Builder
+
 	for {
 		r := scanner.peek()
 
 		if r == '"' {
-			value := scanner.input.Content[scanner.tokenStart+1 : scanner.pos]
+			scanner.consume()
+
+			return scanner.emit(token.String, value.String())
+		}
+
+		// Resynchronise at the end of the line, so that the next line is scanned as it's meant to be.
+		if r == eof || r == '\n' || r == '\r' {
+			scanner.report(UnclosedString, scanner.tokenStart, scanner.pos, "Unclosed string literal.")
 
+			return scanner.emit(token.String, value.String())
+		}
+
+		scanner.decodeNext(&value, math.MaxInt, raw)
+	}
+}
+
+// Scan the string that starts at the current position if its value is the same as its content, which is the case when
+// it's terminated on the same line, is valid UTF-8 and has no control characters, nor escape sequences (unless it's
+// raw). Such a string doesn't need a copy of its value, nor a diagnostic.
+//
+// Returns false, without consuming anything, if the string doesn't qualify.
+func (scanner *Scanner) scanPlainString(raw bool) (token.Token, bool) {
+	data := scanner.ahead(1)
+
+	for idx := 0; idx < len(data); {
+		c := data[idx]
+
+		if c >= utf8.RuneSelf {
+			r, width := utf8.DecodeRuneInString(data[idx:])
+
+			if (r == utf8.RuneError && width == 1) || isControl(r) {
+				break
+			}
+
+			idx += width
+
+			continue
+		}
+
+		if c == '"' {
+			if scanner.mapping {
+				scanner.addSegment(0, idx, scanner.pos, scanner.pos+idx)
+			}
+
+			scanner.pos += idx + 1
+
+			return scanner.emit(token.String, data[:idx]), true
+		}
+
+		if c == '\\' && !raw || c == '\n' || c == '\r' || asciiClass[c]&classControl != 0 {
+			break
+		}
+
+		idx++
+	}
+
+	return token.Token{}, false
+}
+
+// Keep reading data until the termination of the multi-line string.
+// A multi-line string is terminated if:
+// - We hit the string termination characters (3 quotes).
+// - We hit EOF (this means an error, beacused the string isn't properly terminated).
+//
+// The value of the string is its content with the indentation that all of its lines have in common removed. When the
+// opening quotes are followed by a line break, or the closing quotes are preceded by a line containing only
+// whitespace, those lines aren't part of the value. Unless the string is raw, escape sequences are decoded after the
+// indentation is removed. [Segments] maps the value back to the content.
+func (scanner *Scanner) scanMultilineString(raw bool) token.Token {
+	start := scanner.pos
+
+	for !scanner.lookingAt(`"""`) {
+		if scanner.atEOF() {
+			scanner.report(UnclosedString, scanner.tokenStart, scanner.pos, "Unclosed multi-line string literal.")
+
+			return scanner.emit(token.String, scanner.dedent(start, scanner.pos, raw))
+		}
+
+		// An escaped quote doesn't terminate the string.
+		if scanner.consume() == '\\' && !raw && !scanner.atEOF() {
 			scanner.consume()
+		}
+	}
+
+	end := scanner.pos
+	value := scanner.dedent(start, end, raw)
+	scanner.pos = end + 3
+
+	return scanner.emit(token.String, value)
+}
+
+// Returns the value of the content of a multi-line string between start and end, as described by
+// [Scanner.scanMultilineString].
+func (scanner *Scanner) dedent(start, end int, raw bool) string {
+	content := scanner.slice(start, end)
+
+	// Split the content into lines, each as the offsets of its text and of its line break.
+	type line struct {
+		start, end, breakEnd int
+	}
+
+	var lines []line
+
+	for offset := 0; ; {
+		idx := strings.IndexAny(content[offset:], "\r\n")
+
+		if idx < 0 {
+			lines = append(lines, line{offset, len(content), len(content)})
+
+			break
+		}
+
+		next := offset + idx + 1
+
+		if content[next-1] == '\r' && next < len(content) && content[next] == '\n' {
+			next++
+		}
+
+		lines = append(lines, line{offset, offset + idx, next})
+		offset = next
+	}
+
+	isBlank := func(l line) bool {
+		return strings.Trim(content[l.start:l.end], " \t") == ""
+	}
+
+	if len(lines) > 1 && isBlank(lines[0]) {
+		lines = lines[1:]
+	}
+
+	if last := len(lines) - 1; last > 0 && isBlank(lines[last]) {
+		lines = lines[:last]
+		lines[last-1].breakEnd = lines[last-1].end
+	}
+
+	// The common indentation is the longest prefix of whitespace that all lines with text share.
+	indent, first := "", true
+
+	for _, l := range lines {
+		if isBlank(l) {
+			continue
+		}
+
+		text := content[l.start:l.end]
+		lead := text[:len(text)-len(strings.TrimLeft(text, " \t"))]
 
-			return scanner.emit(token.String, value)
+		if first {
+			indent, first = lead, false
+
+			continue
 		}
 
-		if r == 0 || r == '\n' {
-			return scanner.emit(token.Error, "Unclosed string literal.")
+		n := 0
+
+		for n < len(indent) && n < len(lead) && indent[n] == lead[n] {
+			n++
 		}
 
+		indent = indent[:n]
+	}
+
+	var value strings.Builder
+
+	for _, l := range lines {
+		if !isBlank(l) {
+			scanner.decode(&value, start+l.start+len(indent), start+l.end, raw)
+		}
+
+		if scanner.mapping {
+			scanner.addSegment(value.Len(), value.Len()+l.breakEnd-l.end, start+l.end, start+l.breakEnd)
+		}
+
+		value.WriteString(content[l.end:l.breakEnd])
+	}
+
+	return value.String()
+}
+
+// Write the value of the data between from and to to value, decoding escape sequences unless raw is set.
+func (scanner *Scanner) decode(value *strings.Builder, from, to int, raw bool) {
+	pos := scanner.pos
+
+	for scanner.pos = from; scanner.pos < to; {
+		scanner.decodeNext(value, to, raw)
+	}
+
+	scanner.pos = pos
+}
+
+// Write the value of the data at the current position to value, which is either a run of bytes that aren't valid UTF-8
+// (up to to), an escape sequence (unless raw is set), a run of control characters or a single character.
+// Bytes that aren't valid UTF-8 and control characters are kept, and a diagnostic is reported for every run of them.
+func (scanner *Scanner) decodeNext(value *strings.Builder, to int, raw bool) {
+	start, valueStart := scanner.pos, value.Len()
+
+	switch n := min(scanner.invalidBytes(), to-scanner.pos); {
+	case n > 0:
+		scanner.report(InvalidEncoding, scanner.pos, scanner.pos+n, "Invalid UTF-8 encoding in string literal.")
+		value.WriteString(scanner.slice(scanner.pos, scanner.pos+n))
+		scanner.pos += n
+
+	case !raw && scanner.peek() == '\\':
+		scanner.scanEscape(value)
+
+	case isControl(scanner.peek()):
+		value.WriteString(scanner.skipControl(" in string literal"))
+
+	default:
+		value.WriteRune(scanner.consume())
+	}
+
+	if scanner.mapping {
+		scanner.addSegment(valueStart, value.Len(), start, scanner.pos)
+	}
+}
+
+// Read the escape sequence that starts at the current position, and write the character it represents to value.
+// The supported escape sequences are:
+// - \", \\, \n, \t and \r.
+// - \u{XXXX}, where XXXX are 1 to 6 hexadecimal digits that form a Unicode code point.
+//
+// A malformed escape sequence is written to value as is, and a diagnostic that covers it is reported.
+// A backslash at the end of the line or the input is left for the string to report as unclosed.
+func (scanner *Scanner) scanEscape(value *strings.Builder) {
+	start := scanner.pos
+
+	scanner.consume()
+
+	r := scanner.peek()
+
+	if r == eof || r == '\n' || r == '\r' {
+		value.WriteByte('\\')
+
+		return
+	}
+
+	if c, ok := escapeMap[r]; ok {
 		scanner.consume()
+		value.WriteRune(c)
+
+		return
 	}
+
+	scanner.consume()
+
+	if r == 'u' {
+		if cp, ok := scanner.scanCodePoint(); ok {
+			value.WriteRune(cp)
+
+			return
+		}
+
+		scanner.report(InvalidEscape, start, scanner.pos,
+			"Invalid Unicode escape sequence, expected '\\u{XXXX}' with a valid code point.")
+	} else {
+		scanner.report(InvalidEscape, start, scanner.pos, "Invalid escape sequence '\\"+string(r)+"'.")
+	}
+
+	value.WriteString(scanner.slice(start, scanner.pos))
 }
 
-// Keep reading data until the termination of the number.
-// A number is terminated if:
-// - We hit a character isn't a number (including EOF).
-func (scanner *Scanner) scanNumber() token.Token {
+// Read the "{XXXX}" part of a Unicode escape sequence.
+// Reading stops at the first character that isn't a hexadecimal digit or the closing brace, so a malformed sequence
+// never swallows the quote that terminates the string.
+//
+// Returns the code point, and whether it's well-formed and valid.
+func (scanner *Scanner) scanCodePoint() (rune, bool) {
+	if scanner.peek() != '{' {
+		return 0, false
+	}
+
+	scanner.consume()
+
+	var cp rune
+
+	digits := 0
+
 	for {
 		r := scanner.peek()
+		d := hexValue(r)
 
-		if !unicode.IsDigit(r) {
+		if d < 0 {
 			break
 		}
 
 		scanner.consume()
+
+		if digits++; digits <= 6 {
+			cp = cp<<4 | rune(d)
+		}
+	}
+
+	if scanner.peek() != '}' {
+		return 0, false
+	}
+
+	scanner.consume()
+
+	return cp, digits > 0 && digits <= 6 && utf8.ValidRune(cp)
+}
+
+// Keep reading data until the termination of the number.
+// A number consists of:
+//   - An optional sign ("+" or "-").
+//   - Either a prefix ("0x", "0o" or "0b") followed by hexadecimal, octal or binary digits, or decimal digits that are
+//     optionally followed by a fraction (".5") and an exponent ("e10", "E-3").
+//
+// Digits can be separated by a single underscore ("1_000"). A number with a fraction or an exponent is a "Float" token,
+// any other number an "Integer" token. The value of both is the raw text of the number.
+//
+// A malformed number, including any letters or digits that directly follow it, results in a single diagnostic.
+func (scanner *Scanner) scanNumber() token.Token {
+	if r := scanner.peek(); r == '+' || r == '-' {
+		scanner.consume()
+	}
+
+	t, problem := token.Integer, ""
+
+	if prefix, base := scanner.numberPrefix(); base != 0 {
+		scanner.pos += len(prefix)
+
+		if digits, ok := scanner.scanDigits(base); digits == 0 {
+			problem = "expected digits after '" + prefix + "'"
+		} else if !ok {
+			problem = "'_' must separate digits"
+		}
+	} else {
+		_, ok := scanner.scanDigits(10)
+
+		if scanner.peek() == '.' {
+			t = token.Float
+			scanner.consume()
+
+			if digits, fracOK := scanner.scanDigits(10); digits == 0 {
+				problem = "expected digits after the decimal point"
+			} else {
+				ok = ok && fracOK
+			}
+		}
+
+		if r := scanner.peek(); problem == "" && (r == 'e' || r == 'E') {
+			t = token.Float
+			scanner.consume()
+
+			if r := scanner.peek(); r == '+' || r == '-' {
+				scanner.consume()
+			}
+
+			if digits, expOK := scanner.scanDigits(10); digits == 0 {
+				problem = "expected digits in the exponent"
+			} else {
+				ok = ok && expOK
+			}
+		}
+
+		if problem == "" && !ok {
+			problem = "'_' must separate digits"
+		}
+	}
+
+	// Letters or digits that directly follow a number are part of it, such as the "2" in "0b102".
+	if r := scanner.peek(); isWordChar(r) {
+		if problem == "" {
+			problem = "unexpected character '" + string(r) + "'"
+		}
+
+		for isWordChar(scanner.peek()) {
+			scanner.consume()
+		}
 	}
 
-	value := scanner.input.Content[scanner.tokenStart:scanner.pos]
+	if problem != "" {
+		scanner.report(InvalidNumber, scanner.tokenStart, scanner.pos, "Invalid number, "+problem+".")
+	}
+
+	return scanner.emit(t, scanner.slice(scanner.tokenStart, scanner.pos))
+}
+
+// Returns the prefix of the number at the current position, and the base that it selects.
+// If there's no prefix, the base is 0.
+func (scanner *Scanner) numberPrefix() (string, int) {
+	for _, prefix := range []string{"0x", "0X", "0o", "0O", "0b", "0B"} {
+		if scanner.lookingAt(prefix) {
+			return prefix, numberBases[prefix[1]|0x20]
+		}
+	}
+
+	return "", 0
+}
+
+// Keep reading data until a character is encountered that is neither a digit in the given base nor an underscore.
+//
+// Returns the number of digits that were read, and whether every underscore was placed between 2 digits.
+func (scanner *Scanner) scanDigits(base int) (int, bool) {
+	digits, ok, prev := 0, true, '_'
+
+	for {
+		r := scanner.peek()
 
-	return scanner.emit(token.Number, value)
+		if r == '_' {
+			ok = ok && prev != '_'
+		} else if d := hexValue(r); d < 0 || d >= base {
+			break
+		} else {
+			digits++
+		}
+
+		prev = scanner.consume()
+	}
+
+	return digits, ok && (digits == 0 || prev != '_')
 }
 
 // Keep reading data until the termination of the identifier.
@@ -197,35 +784,209 @@ func (scanner *Scanner) scanNumber() token.Token {
 // If the identifier is a boolean value (either "true" or "false"), a "Bool" token is emitted, in any other case, an
 // "Ident" token is emitted.
 func (scanner *Scanner) scanIdentifier() token.Token {
+	for isWordChar(scanner.peek()) {
+		scanner.consume()
+	}
+
+	value := scanner.slice(scanner.tokenStart, scanner.pos)
+
+	if value == "true" || value == "false" {
+		return scanner.emit(token.Bool, value)
+	}
+
+	return scanner.emit(token.Ident, value)
+}
+
+// Keep skipping whitespace and comments until something else is encountered.
+func (scanner *Scanner) skipTrivia() {
+	for {
+		if _, ok := scanner.scanTrivia(); !ok {
+			break
+		}
+	}
+}
+
+// Keep collecting whitespace and comments until something else is encountered.
+// When collecting the trivia that trails a token, the collection stops after the first line break.
+func (scanner *Scanner) collectTrivia(trailing bool) []token.Trivia {
+	var trivia []token.Trivia
+
+	for {
+		tr, ok := scanner.scanTrivia()
+
+		if !ok {
+			break
+		}
+
+		trivia = append(trivia, tr)
+
+		if trailing && tr.Kind == token.Newline {
+			break
+		}
+	}
+
+	return trivia
+}
+
+// Scan a single piece of trivia at the current position, if there's one.
+// A piece of trivia is either:
+// - A run of whitespace characters, excluding line breaks.
+// - A single line break ("\n", "\r\n" or "\r").
+// - A line comment, excluding the line break that terminates it.
+// - A block comment.
+// - A skipped run of characters that can't start a token, of control characters or of bytes that aren't valid UTF-8.
+//
+// If a block comment isn't terminated, it runs until EOF, and a diagnostic that points at its opening is reported.
+func (scanner *Scanner) scanTrivia() (token.Trivia, bool) {
+	start := scanner.pos
+
+	// The data in front of the trivia isn't needed anymore, see [Scanner.read].
+	scanner.keep = start
+
+	var kind token.TriviaKind
+
+	switch r := scanner.peek(); {
+	case r == '\n' || r == '\r':
+		kind = token.Newline
+
+		if scanner.consume() == '\r' && scanner.peek() == '\n' {
+			scanner.consume()
+		}
+
+	case isSpace(r):
+		kind = token.Whitespace
+		scanner.skipWhitespace()
+
+	case r == '/' && scanner.lookingAt("//"), r == '#':
+		kind = token.LineComment
+		scanner.skipLineComment()
+
+	case r == '/' && scanner.lookingAt("/*"):
+		kind = token.BlockComment
+
+		if !scanner.skipBlockComment() {
+			scanner.report(UnterminatedComment, start, start+2, "Unterminated block comment.")
+		}
+
+	case r == utf8.RuneError && scanner.invalidBytes() > 0:
+		kind = token.Skipped
+		scanner.pos += scanner.invalidBytes()
+		scanner.report(InvalidEncoding, start, scanner.pos, "Invalid UTF-8 encoding.")
+
+	case isControl(r):
+		kind = token.Skipped
+		scanner.skipControl("")
+
+	case r != eof && !scanner.startsToken(r):
+		kind = token.Skipped
+		scanner.skipInvalid()
+
+		if value := scanner.slice(start, scanner.pos); utf8.RuneCountInString(value) == 1 {
+			scanner.report(InvalidCharacter, start, scanner.pos, "Invalid character '"+value+"'.")
+		} else {
+			scanner.report(InvalidCharacter, start, scanner.pos, "Invalid characters '"+value+"'.")
+		}
+
+	default:
+		return token.Trivia{}, false
+	}
+
+	return token.Trivia{Kind: kind, Span: text.Span{Start: start, End: scanner.pos}}, true
+}
+
+// Keep reading data until EOF or a character is encountered that starts a token, whitespace, a control character, a
+// comment or bytes that aren't valid UTF-8.
+func (scanner *Scanner) skipInvalid() {
 	for {
 		r := scanner.peek()
 
-		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+		if r == eof || r == '#' || isSpace(r) || isControl(r) || scanner.startsToken(r) ||
+			r == '/' && (scanner.lookingAt("//") || scanner.lookingAt("/*")) ||
+			r == utf8.RuneError && scanner.invalidBytes() > 0 {
 			break
 		}
 
 		scanner.consume()
 	}
+}
+
+// Keep reading data until a character is encountered that isn't a control character, and report the run of control
+// characters that's read, followed by where, as a single diagnostic.
+//
+// Returns the data that's read.
+func (scanner *Scanner) skipControl(where string) string {
+	start := scanner.pos
 
-	value := scanner.input.Content[scanner.tokenStart:scanner.pos]
+	var codes []string
 
-	if value == "true" || value == "false" {
-		return scanner.emit(token.Bool, value)
+	for isControl(scanner.peek()) {
+		codes = append(codes, codePoint(scanner.consume()))
 	}
 
-	return scanner.emit(token.Ident, value)
+	if len(codes) == 1 {
+		scanner.report(ControlCharacter, start, scanner.pos, "Invalid control character "+codes[0]+where+".")
+	} else {
+		scanner.report(ControlCharacter, start, scanner.pos,
+			"Invalid control characters "+strings.Join(codes, " ")+where+".")
+	}
+
+	return scanner.slice(start, scanner.pos)
 }
 
-// Keep reading data until EOF or a non-whitespace character is encountered.
-func (scanner *Scanner) skipWhitespace() {
+// Keep reading data until EOF or a line break is encountered.
+// The line break itself isn't part of the comment.
+func (scanner *Scanner) skipLineComment() {
 	for {
+		// The text of a comment isn't needed, so it doesn't have to stay buffered.
+		scanner.keep = scanner.pos
 		r := scanner.peek()
 
-		if r == 0 {
+		if r == eof || r == '\n' || r == '\r' {
 			break
 		}
 
-		if !unicode.IsSpace(r) {
+		scanner.consume()
+	}
+}
+
+// Keep reading data until the block comment that starts at the current position is terminated.
+// Block comments can be nested, so every "/*" must be matched by a "*/".
+//
+// Returns whether the comment is terminated before EOF.
+func (scanner *Scanner) skipBlockComment() bool {
+	depth := 0
+
+	for !scanner.atEOF() {
+		// The text of a comment isn't needed, so it doesn't have to stay buffered.
+		scanner.keep = scanner.pos
+
+		switch {
+		case scanner.lookingAt("/*"):
+			scanner.pos += 2
+			depth++
+
+		case scanner.lookingAt("*/"):
+			scanner.pos += 2
+			depth--
+
+			if depth == 0 {
+				return true
+			}
+
+		default:
+			scanner.consume()
+		}
+	}
+
+	return false
+}
+
+// Keep reading data until EOF, a line break or a non-whitespace character is encountered.
+func (scanner *Scanner) skipWhitespace() {
+	for {
+		r := scanner.peek()
+
+		if r == '\n' || r == '\r' || !isSpace(r) {
 			break
 		}
 
@@ -233,33 +994,243 @@ func (scanner *Scanner) skipWhitespace() {
 	}
 }
 
+// Reports whether r can be part of an identifier.
+func isWordChar(r rune) bool {
+	if uint32(r) < utf8.RuneSelf {
+		return asciiClass[r]&classWord != 0
+	}
+
+	return r != eof && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// Reports whether r is a whitespace character.
+func isSpace(r rune) bool {
+	if uint32(r) < utf8.RuneSelf {
+		return asciiClass[r]&classSpace != 0
+	}
+
+	return r != eof && unicode.IsSpace(r)
+}
+
+// Reports whether r is a digit, in any script.
+func isDigit(r rune) bool {
+	if uint32(r) < utf8.RuneSelf {
+		return asciiClass[r]&classDigit != 0
+	}
+
+	return r != eof && unicode.IsDigit(r)
+}
+
+// Reports whether r is a control character, such as U+0000, that isn't whitespace.
+func isControl(r rune) bool {
+	if uint32(r) < utf8.RuneSelf {
+		return asciiClass[r]&classControl != 0
+	}
+
+	return r != eof && unicode.IsControl(r) && !unicode.IsSpace(r)
+}
+
+// Returns the notation of r as a Unicode code point, such as "U+0000".
+func codePoint(r rune) string {
+	hex := strings.ToUpper(strconv.FormatInt(int64(r), 16))
+
+	return "U+" + strings.Repeat("0", max(0, 4-len(hex))) + hex
+}
+
+// Reports whether r is a decimal digit.
+func isDecimal(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// Returns the value of r as a hexadecimal digit, or -1 if it isn't one.
+func hexValue(r rune) int {
+	switch {
+	case r >= '0' && r <= '9':
+		return int(r - '0')
+
+	case r >= 'a' && r <= 'f':
+		return int(r-'a') + 10
+
+	case r >= 'A' && r <= 'F':
+		return int(r-'A') + 10
+	}
+
+	return -1
+}
+
+// Reports whether the input continues with s at the current position.
+func (scanner *Scanner) lookingAt(s string) bool {
+	return strings.HasPrefix(scanner.ahead(len(s)), s)
+}
+
+// Reports whether the current position is at the end of the input.
+func (scanner *Scanner) atEOF() bool {
+	return scanner.ahead(1) == ""
+}
+
 // Look at the next character without consuming it.
 func (scanner *Scanner) peek() rune {
-	if scanner.pos >= len(scanner.input.Content) {
-		return 0
+	// An ASCII character is a single byte, so it's complete once it's buffered.
+	if idx := scanner.pos - scanner.base; idx < len(scanner.src) && scanner.src[idx] < utf8.RuneSelf {
+		return rune(scanner.src[idx])
 	}
 
-	r, _ := utf8.DecodeRuneInString(scanner.input.Content[scanner.pos:])
+	if scanner.atEOF() {
+		return eof
+	}
+
+	r, _ := utf8.DecodeRuneInString(scanner.ahead(utf8.UTFMax))
+
+	return r
+}
+
+// Look at the character after the next one without consuming anything.
+func (scanner *Scanner) peekNext() rune {
+	data := scanner.ahead(2 * utf8.UTFMax)
+
+	if data == "" {
+		return eof
+	}
+
+	_, width := utf8.DecodeRuneInString(data)
+
+	if width >= len(data) {
+		return eof
+	}
+
+	r, _ := utf8.DecodeRuneInString(data[width:])
 
 	return r
 }
 
 // Consumes the next character.
 func (scanner *Scanner) consume() rune {
-	r, width := utf8.DecodeRuneInString(scanner.input.Content[scanner.pos:])
+	if idx := scanner.pos - scanner.base; idx < len(scanner.src) && scanner.src[idx] < utf8.RuneSelf {
+		scanner.pos++
+
+		return rune(scanner.src[idx])
+	}
+
+	r, width := utf8.DecodeRuneInString(scanner.ahead(utf8.UTFMax))
 	scanner.pos += width
 
 	return r
 }
 
+// Returns the number of adjacent bytes at the current position that aren't valid UTF-8.
+func (scanner *Scanner) invalidBytes() int {
+	n := 0
+
+	for data := scanner.ahead(utf8.UTFMax); n < len(data); data = scanner.ahead(n + utf8.UTFMax) {
+		r, width := utf8.DecodeRuneInString(data[n:])
+
+		if r != utf8.RuneError || width != 1 {
+			break
+		}
+
+		n++
+	}
+
+	return n
+}
+
+// Returns the data between the offsets start and end, which must be buffered.
+func (scanner *Scanner) slice(start, end int) string {
+	return scanner.src[start-scanner.base : end-scanner.base]
+}
+
+// Returns the data from the current position onwards, after reading from the reader until at least n bytes are
+// buffered or the reader is exhausted.
+func (scanner *Scanner) ahead(n int) string {
+	for scanner.reader != nil && len(scanner.src)-(scanner.pos-scanner.base) < n {
+		scanner.read()
+	}
+
+	return scanner.src[scanner.pos-scanner.base:]
+}
+
+// Read the next chunk of data from the reader into the buffer, dropping the data in front of the token or trivia that's
+// being scanned. The reader is released once it's exhausted or returns an error, or after [maxEmptyReads] reads in a
+// row that return nothing, which is reported as [io.ErrNoProgress].
+//
+// The data is appended to the buffer, which grows in place, so that a long token isn't copied for every chunk. Once
+// most of the buffer isn't needed anymore, a new buffer that only holds the data that's kept is started instead.
+// Strings that refer to the old buffer stay valid, because a [strings.Builder] never overwrites data.
+func (scanner *Scanner) read() {
+	n, err := scanner.reader.Read(scanner.chunk)
+
+	if n == 0 && err == nil {
+		if scanner.emptyReads++; scanner.emptyReads >= maxEmptyReads {
+			scanner.err, scanner.reader = io.ErrNoProgress, nil
+		}
+
+		return
+	}
+
+	scanner.emptyReads = 0
+
+	if kept := scanner.src[scanner.keep-scanner.base:]; 2*len(kept) <= len(scanner.src) {
+		scanner.buf = strings.Builder{}
+		scanner.buf.Grow(len(kept) + len(scanner.chunk))
+		scanner.buf.WriteString(kept)
+		scanner.base = scanner.keep
+	}
+
+	scanner.buf.Write(scanner.chunk[:n])
+	scanner.src = scanner.buf.String()
+
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			scanner.err = err
+		}
+
+		scanner.reader = nil
+	}
+}
+
+// Record a problem with the data between start and end.
+func (scanner *Scanner) report(code Code, start, end int, msg string) {
+	d := Diagnostic{
+		Code:     code,
+		Severity: Error,
+		Span: text.Span{
+			Start: start,
+			End:   end,
+		},
+		Message: msg,
+	}
+
+	if scanner.file != nil {
+		d.Pos = scanner.file.Pos(start)
+	}
+
+	scanner.diagnostics = append(scanner.diagnostics, d)
+}
+
 // Emit a token that represents the scanned data.
 func (scanner *Scanner) emit(t token.Type, lit string) token.Token {
-	return token.Token{
+	return scanner.tokenAt(t, lit, scanner.tokenStart, scanner.pos)
+}
+
+// Returns a token that represents the data between start and end.
+func (scanner *Scanner) tokenAt(t token.Type, lit string, start, end int) token.Token {
+	// A literal that refers to the buffer of a reader would keep the whole chunk in memory.
+	if scanner.chunk != nil {
+		lit = strings.Clone(lit)
+	}
+
+	tok := token.Token{
 		Type:    t,
 		Literal: lit,
 		Span: text.Span{
-			Start: scanner.tokenStart,
-			End:   scanner.pos,
+			Start: start,
+			End:   end,
 		},
 	}
+
+	if scanner.file != nil {
+		tok.Pos = scanner.file.Pos(start)
+	}
+
+	return tok
 }
@@ -27,7 +27,13 @@
 package scanner_test
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/kdeconinck/lens/internal/pkg/assert"
 	"github.com/kdeconinck/lens/internal/pkg/lux/scanner"
@@ -313,16 +319,16 @@ func TestScanner_NextToken(t *testing.T) {
 		}
 	})
 
-	t.Run("When scanning an invalid 'string' (newline), the 'Error' token is returned'.", func(t *testing.T) {
+	t.Run("When scanning a 'string' with escape sequences, the value is decoded'.", func(t *testing.T) {
 		t.Parallel() // Enable parallel execution.
 
 		// Arrange.
-		scanner := newScanner("\"Hello\n")
+		scanner := newScanner(`"\"a\\b\"\n\t\r"`)
 
 		// Act.
 		wantTokens := newTokenSet(
-			newValueToken(token.Error, "Unclosed string literal.", 0, 6),
-			newToken(token.EOF, 7, 7),
+			newValueToken(token.String, "\"a\\b\"\n\t\r", 0, 16),
+			newToken(token.EOF, 16, 16),
 		)
 
 		for idx, want := range wantTokens {
@@ -330,13 +336,223 @@ func TestScanner_NextToken(t *testing.T) {
 
 			// Assert.
 			assert.Equalf(t, got, want, "\n\n"+
-				"UT Name:  When scanning a 'string', the 'String' token is returned'.\n"+
+				"UT Name:  When scanning a 'string' with escape sequences, the value is decoded'.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+	})
+
+	t.Run("When scanning a 'string' with Unicode escape sequences, the value is decoded'.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner(`"\u{41}\u{1F680}"`)
+
+		// Act.
+		wantTokens := newTokenSet(
+			newValueToken(token.String, "A🚀", 0, 17),
+			newToken(token.EOF, 17, 17),
+		)
+
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When scanning a 'string' with Unicode escape sequences, the value is decoded'.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+	})
+
+	t.Run("When scanning a 'string' with an invalid escape sequence, it's kept'.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner(`"a\qb" c`)
+
+		// Act.
+		wantTokens := newTokenSet(
+			newValueToken(token.String, "a\\qb", 0, 6),
+			newValueToken(token.Ident, "c", 7, 8),
+			newToken(token.EOF, 8, 8),
+		)
+
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When scanning a 'string' with an invalid escape sequence, it's kept'.\n"+
 				"\033[32mExpected: #%d - %s\033[0m\n"+
 				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
 		}
 	})
 
-	t.Run("When scanning an invalid 'string' (EOF), the 'Error' token is returned'.", func(t *testing.T) {
+	t.Run("When scanning a 'string' with invalid Unicode escape sequences, they're kept'.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner(`"\u41\u{}\u{D800}\u{1234567}\u{4"`)
+
+		// Act.
+		wantTokens := newTokenSet(
+			newValueToken(token.String, "\\u41\\u{}\\u{D800}\\u{1234567}\\u{4", 0, 33),
+			newToken(token.EOF, 33, 33),
+		)
+
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When scanning a 'string' with invalid Unicode escape sequences, they're kept'.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+	})
+
+	t.Run("When scanning a multi-line 'string', the common indentation is removed'.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("\"\"\"\n    class A {\n        int x;\n    }\n    \"\"\"")
+
+		// Act.
+		wantTokens := newTokenSet(
+			newValueToken(token.String, "class A {\n    int x;\n}", 0, 46),
+			newToken(token.EOF, 46, 46),
+		)
+
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When scanning a multi-line 'string', the common indentation is removed'.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+	})
+
+	t.Run("When scanning a raw 'string', escape sequences aren't decoded'.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner(`r"C:\temp\new" x`)
+
+		// Act.
+		wantTokens := newTokenSet(
+			newValueToken(token.String, `C:\temp\new`, 0, 14),
+			newValueToken(token.Ident, "x", 15, 16),
+			newToken(token.EOF, 16, 16),
+		)
+
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When scanning a raw 'string', escape sequences aren't decoded'.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+	})
+
+	t.Run("When scanning a raw multi-line 'string', the common indentation is removed'.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("r\"\"\"\n\t\t\\d+\n\t\t\t\"raw\"\n\t\t\"\"\"")
+
+		// Act.
+		wantTokens := newTokenSet(
+			newValueToken(token.String, "\\d+\n\t\"raw\"", 0, 25),
+			newToken(token.EOF, 25, 25),
+		)
+
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When scanning a raw multi-line 'string', the common indentation is removed'.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+	})
+
+	t.Run("When scanning a multi-line 'string' with invalid escapes, they're kept'.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("\"\"\"\n  a\\q\n  \\u{110000}\n  \"\"\"")
+
+		// Act.
+		wantTokens := newTokenSet(
+			newValueToken(token.String, "a\\q\n\\u{110000}", 0, 28),
+			newToken(token.EOF, 28, 28),
+		)
+
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When scanning a multi-line 'string' with invalid escapes, they're kept'.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+	})
+
+	t.Run("When scanning an invalid multi-line 'string' (EOF), the 'String' token runs until EOF'.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("\"\"\"\n  unclosed")
+
+		// Act.
+		wantTokens := newTokenSet(
+			newValueToken(token.String, "unclosed", 0, 14),
+			newToken(token.EOF, 14, 14),
+		)
+
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When scanning an invalid multi-line 'string' (EOF), the 'String' token runs until EOF'.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+	})
+
+	t.Run("When scanning an invalid 'string' (newline), the 'String' token ends at the newline'.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("\"Hello\nnext")
+
+		// Act.
+		wantTokens := newTokenSet(
+			newValueToken(token.String, "Hello", 0, 6),
+			newValueToken(token.Ident, "next", 7, 11),
+			newToken(token.EOF, 11, 11),
+		)
+
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When scanning an invalid 'string' (newline), the 'String' token ends at the newline'.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+	})
+
+	t.Run("When scanning an invalid 'string' (EOF), the 'String' token ends at EOF'.", func(t *testing.T) {
 		t.Parallel() // Enable parallel execution.
 
 		// Arrange.
@@ -344,7 +560,7 @@ func TestScanner_NextToken(t *testing.T) {
 
 		// Act.
 		wantTokens := newTokenSet(
-			newValueToken(token.Error, "Unclosed string literal.", 0, 6),
+			newValueToken(token.String, "Hello", 0, 6),
 			newToken(token.EOF, 6, 6),
 		)
 
@@ -353,13 +569,13 @@ func TestScanner_NextToken(t *testing.T) {
 
 			// Assert.
 			assert.Equalf(t, got, want, "\n\n"+
-				"UT Name:  When scanning a 'string', the 'String' token is returned'.\n"+
+				"UT Name:  When scanning an invalid 'string' (EOF), the 'String' token ends at EOF'.\n"+
 				"\033[32mExpected: #%d - %s\033[0m\n"+
 				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
 		}
 	})
 
-	t.Run("When scanning a 'number', the 'Number' token is returned'.", func(t *testing.T) {
+	t.Run("When scanning an 'integer', the 'Integer' token is returned'.", func(t *testing.T) {
 		t.Parallel() // Enable parallel execution.
 
 		// Arrange.
@@ -367,7 +583,7 @@ func TestScanner_NextToken(t *testing.T) {
 
 		// Act.
 		wantTokens := newTokenSet(
-			newValueToken(token.Number, "123", 0, 3),
+			newValueToken(token.Integer, "123", 0, 3),
 			newToken(token.EOF, 3, 3),
 		)
 
@@ -376,7 +592,92 @@ func TestScanner_NextToken(t *testing.T) {
 
 			// Assert.
 			assert.Equalf(t, got, want, "\n\n"+
-				"UT Name:  When scanning a 'number', the 'Number' token is returned'.\n"+
+				"UT Name:  When scanning an 'integer', the 'Integer' token is returned'.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+	})
+
+	t.Run("When scanning integers in any base, the 'Integer' token is returned'.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("-42 +7 1_000 0x1F 0o17 0b1010")
+
+		// Act.
+		wantTokens := newTokenSet(
+			newValueToken(token.Integer, "-42", 0, 3),
+			newValueToken(token.Integer, "+7", 4, 6),
+			newValueToken(token.Integer, "1_000", 7, 12),
+			newValueToken(token.Integer, "0x1F", 13, 17),
+			newValueToken(token.Integer, "0o17", 18, 22),
+			newValueToken(token.Integer, "0b1010", 23, 29),
+			newToken(token.EOF, 29, 29),
+		)
+
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When scanning integers in any base, the 'Integer' token is returned'.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+	})
+
+	t.Run("When scanning numbers with a fraction or an exponent, the 'Float' token is returned'.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("1.5 -0.25 1e10 2.5E-3 6.022_140e+23")
+
+		// Act.
+		wantTokens := newTokenSet(
+			newValueToken(token.Float, "1.5", 0, 3),
+			newValueToken(token.Float, "-0.25", 4, 9),
+			newValueToken(token.Float, "1e10", 10, 14),
+			newValueToken(token.Float, "2.5E-3", 15, 21),
+			newValueToken(token.Float, "6.022_140e+23", 22, 35),
+			newToken(token.EOF, 35, 35),
+		)
+
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When scanning numbers with a fraction or an exponent, the 'Float' token is returned'.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+	})
+
+	t.Run("When scanning malformed numbers, the token they resemble is returned'.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("1. 0x 1e 1__0 2_ 0b102 12ab 0x_1")
+
+		// Act.
+		wantTokens := newTokenSet(
+			newValueToken(token.Float, "1.", 0, 2),
+			newValueToken(token.Integer, "0x", 3, 5),
+			newValueToken(token.Float, "1e", 6, 8),
+			newValueToken(token.Integer, "1__0", 9, 13),
+			newValueToken(token.Integer, "2_", 14, 16),
+			newValueToken(token.Integer, "0b102", 17, 22),
+			newValueToken(token.Integer, "12ab", 23, 27),
+			newValueToken(token.Integer, "0x_1", 28, 32),
+			newToken(token.EOF, 32, 32),
+		)
+
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When scanning malformed numbers, the token they resemble is returned'.\n"+
 				"\033[32mExpected: #%d - %s\033[0m\n"+
 				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
 		}
@@ -497,16 +798,41 @@ func TestScanner_NextToken(t *testing.T) {
 		}
 	})
 
-	t.Run("When scanning anything that's not valid, the 'Error' token is returned'.", func(t *testing.T) {
+	t.Run("When scanning anything that's not valid, it's skipped'.", func(t *testing.T) {
 		t.Parallel() // Enable parallel execution.
 
 		// Arrange.
-		scanner := newScanner("@")
+		scanner := newScanner("@ a $%^ b")
 
 		// Act.
 		wantTokens := newTokenSet(
-			newValueToken(token.Error, "Invalid character '@'.", 0, 1),
-			newToken(token.EOF, 1, 1),
+			newValueToken(token.Ident, "a", 2, 3),
+			newValueToken(token.Ident, "b", 8, 9),
+			newToken(token.EOF, 9, 9),
+		)
+
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When scanning anything that's not valid, it's skipped'.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+	})
+
+	t.Run("When scanning line comments, the comments are ignored.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("// A comment.\na # Another comment.\r\n#\nb //")
+
+		// Act.
+		wantTokens := newTokenSet(
+			newValueToken(token.Ident, "a", 14, 15),
+			newValueToken(token.Ident, "b", 38, 39),
+			newToken(token.EOF, 42, 42),
 		)
 
 		for idx, want := range wantTokens {
@@ -514,7 +840,151 @@ func TestScanner_NextToken(t *testing.T) {
 
 			// Assert.
 			assert.Equalf(t, got, want, "\n\n"+
-				"UT Name:  When scanning anything that's not valid, the 'Error' token is returned'.\n"+
+				"UT Name:  When scanning line comments, the comments are ignored.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+	})
+
+	t.Run("When scanning nested block comments, the comments are ignored.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("/* A /* nested */ comment. */a/**/b")
+
+		// Act.
+		wantTokens := newTokenSet(
+			newValueToken(token.Ident, "a", 29, 30),
+			newValueToken(token.Ident, "b", 34, 35),
+			newToken(token.EOF, 35, 35),
+		)
+
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When scanning nested block comments, the comments are ignored.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+	})
+
+	t.Run("When scanning an unterminated block comment, it runs until EOF.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("a /* b /* c */")
+
+		// Act.
+		wantTokens := newTokenSet(
+			newValueToken(token.Ident, "a", 0, 1),
+			newToken(token.EOF, 14, 14),
+		)
+
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When scanning an unterminated block comment, it runs until EOF.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+	})
+
+	t.Run("When scanning bytes that aren't valid UTF-8, they're skipped.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("a \xE2\x82 b")
+
+		// Act.
+		wantTokens := newTokenSet(
+			newValueToken(token.Ident, "a", 0, 1),
+			newValueToken(token.Ident, "b", 5, 6),
+			newToken(token.EOF, 6, 6),
+		)
+
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When scanning bytes that aren't valid UTF-8, they're skipped.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+	})
+
+	t.Run("When scanning a 'string' with bytes that aren't valid UTF-8, they're kept.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("\"a\xFFb\xFF\" c")
+
+		// Act.
+		wantTokens := newTokenSet(
+			newValueToken(token.String, "a\xFFb\xFF", 0, 6),
+			newValueToken(token.Ident, "c", 7, 8),
+			newToken(token.EOF, 8, 8),
+		)
+
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When scanning a 'string' with bytes that aren't valid UTF-8, they're kept.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+	})
+
+	t.Run("When scanning a NUL character, it's skipped instead of ending the input.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("a\x00b \x01\x7F c")
+
+		// Act.
+		wantTokens := newTokenSet(
+			newValueToken(token.Ident, "a", 0, 1),
+			newValueToken(token.Ident, "b", 2, 3),
+			newValueToken(token.Ident, "c", 7, 8),
+			newToken(token.EOF, 8, 8),
+		)
+
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When scanning a NUL character, it's skipped instead of ending the input.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+	})
+
+	t.Run("When scanning a 'string' with control characters, they're kept.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("\"a\x00b\" \"\"\"\n  c\x1B\n  \"\"\"")
+
+		// Act.
+		wantTokens := newTokenSet(
+			newValueToken(token.String, "a\x00b", 0, 5),
+			newValueToken(token.String, "c\x1B", 6, 20),
+			newToken(token.EOF, 20, 20),
+		)
+
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When scanning a 'string' with control characters, they're kept.\n"+
 				"\033[32mExpected: #%d - %s\033[0m\n"+
 				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
 		}
@@ -547,9 +1017,7 @@ extension: ".cs" {
 		wantTokens := []token.Token{
 			newValueToken(token.Ident, "version", 0, 7),
 			newToken(token.Equals, 8, 9),
-			newValueToken(token.Number, "1", 10, 11),
-			newToken(token.Dot, 11, 12),
-			newValueToken(token.Number, "0", 12, 13),
+			newValueToken(token.Float, "1.0", 10, 13),
 			newValueToken(token.Ident, "extension", 14, 23),
 			newToken(token.Colon, 23, 24),
 			newValueToken(token.String, ".cs", 25, 30),
@@ -631,6 +1099,493 @@ extension: ".cs" {
 	})
 }
 
+// UT: Verify that scanning tokens doesn't allocate memory for every token.
+func TestScanner_NextToken_Allocations(t *testing.T) {
+	// No parallel execution, because allocations can't be counted while other tests are running.
+
+	// Arrange.
+	allocs := func(content string) float64 {
+		input := &text.Input{Content: content}
+
+		return testing.AllocsPerRun(10, func() {
+			scanner := scanner.New(input)
+
+			for scanner.NextToken().Type != token.EOF {
+			}
+		})
+	}
+
+	// Act.
+	got, want := allocs(library), allocs(config)
+
+	// Assert.
+	assert.Equalf(t, got, want, "\n\n"+
+		"UT Name:  When scanning a larger input, the number of allocations stays the same.\n"+
+		"\033[32mExpected: %v allocations\033[0m\n"+
+		"\033[31mActual:   %v allocations\033[0m\n\n", want, got)
+}
+
+// UT: Verify that the problems in the input are reported as diagnostics.
+func TestScanner_Diagnostics(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	unicodeEscape := "Invalid Unicode escape sequence, expected '\\u{XXXX}' with a valid code point."
+
+	for tcName, tc := range map[string]struct {
+		contentInput string
+		want         []scanner.Diagnostic
+	}{
+		"When scanning invalid characters, every run of them is reported once.": {
+			contentInput: "@ a $%^ b",
+			want: []scanner.Diagnostic{
+				newDiagnostic(scanner.InvalidCharacter, 0, 1, "Invalid character '@'."),
+				newDiagnostic(scanner.InvalidCharacter, 4, 7, "Invalid characters '$%^'."),
+			},
+		},
+		"When scanning bytes that aren't valid UTF-8, every run of them is reported once.": {
+			contentInput: "a \xE2\x82 b \xFF",
+			want: []scanner.Diagnostic{
+				newDiagnostic(scanner.InvalidEncoding, 2, 4, "Invalid UTF-8 encoding."),
+				newDiagnostic(scanner.InvalidEncoding, 7, 8, "Invalid UTF-8 encoding."),
+			},
+		},
+		"When scanning a 'string' with bytes that aren't valid UTF-8, they're reported.": {
+			contentInput: "\"a\xFFb\xFF\" c",
+			want: []scanner.Diagnostic{
+				newDiagnostic(scanner.InvalidEncoding, 2, 3, "Invalid UTF-8 encoding in string literal."),
+				newDiagnostic(scanner.InvalidEncoding, 4, 5, "Invalid UTF-8 encoding in string literal."),
+			},
+		},
+		"When scanning an unclosed 'string', the string up to the end of the line is reported.": {
+			contentInput: "\"Hello\nnext \"World",
+			want: []scanner.Diagnostic{
+				newDiagnostic(scanner.UnclosedString, 0, 6, "Unclosed string literal."),
+				newDiagnostic(scanner.UnclosedString, 12, 18, "Unclosed string literal."),
+			},
+		},
+		"When scanning an unclosed multi-line 'string', the string up to EOF is reported.": {
+			contentInput: "\"\"\"\n  unclosed",
+			want: []scanner.Diagnostic{
+				newDiagnostic(scanner.UnclosedString, 0, 14, "Unclosed multi-line string literal."),
+			},
+		},
+		"When scanning a 'string' with invalid escape sequences, each is reported.": {
+			contentInput: `"a\qb" "\u41\u{}\u{D800}\u{1234567}\u{4"`,
+			want: []scanner.Diagnostic{
+				newDiagnostic(scanner.InvalidEscape, 2, 4, "Invalid escape sequence '\\q'."),
+				newDiagnostic(scanner.InvalidEscape, 8, 10, unicodeEscape),
+				newDiagnostic(scanner.InvalidEscape, 12, 16, unicodeEscape),
+				newDiagnostic(scanner.InvalidEscape, 16, 24, unicodeEscape),
+				newDiagnostic(scanner.InvalidEscape, 24, 35, unicodeEscape),
+				newDiagnostic(scanner.InvalidEscape, 35, 39, unicodeEscape),
+			},
+		},
+		"When scanning a multi-line 'string' with invalid escape sequences, they're reported in place.": {
+			contentInput: "\"\"\"\n  a\\q\n  \\u{110000}\n  \"\"\"",
+			want: []scanner.Diagnostic{
+				newDiagnostic(scanner.InvalidEscape, 7, 9, "Invalid escape sequence '\\q'."),
+				newDiagnostic(scanner.InvalidEscape, 12, 22, unicodeEscape),
+			},
+		},
+		"When scanning an unterminated block comment, its opening is reported.": {
+			contentInput: "a /* b /* c */",
+			want: []scanner.Diagnostic{
+				newDiagnostic(scanner.UnterminatedComment, 2, 4, "Unterminated block comment."),
+			},
+		},
+		"When scanning malformed numbers, each is reported.": {
+			contentInput: "1. 0x 1e 1__0 2_ 0b102 12ab 0x_1",
+			want: []scanner.Diagnostic{
+				newDiagnostic(scanner.InvalidNumber, 0, 2, "Invalid number, expected digits after the decimal point."),
+				newDiagnostic(scanner.InvalidNumber, 3, 5, "Invalid number, expected digits after '0x'."),
+				newDiagnostic(scanner.InvalidNumber, 6, 8, "Invalid number, expected digits in the exponent."),
+				newDiagnostic(scanner.InvalidNumber, 9, 13, "Invalid number, '_' must separate digits."),
+				newDiagnostic(scanner.InvalidNumber, 14, 16, "Invalid number, '_' must separate digits."),
+				newDiagnostic(scanner.InvalidNumber, 17, 22, "Invalid number, unexpected character '2'."),
+				newDiagnostic(scanner.InvalidNumber, 23, 27, "Invalid number, unexpected character 'a'."),
+				newDiagnostic(scanner.InvalidNumber, 28, 32, "Invalid number, '_' must separate digits."),
+			},
+		},
+		"When scanning control characters, every run of them is reported once.": {
+			contentInput: "a \x00\x01 b @\x7F",
+			want: []scanner.Diagnostic{
+				newDiagnostic(scanner.ControlCharacter, 2, 4, "Invalid control characters U+0000 U+0001."),
+				newDiagnostic(scanner.InvalidCharacter, 7, 8, "Invalid character '@'."),
+				newDiagnostic(scanner.ControlCharacter, 8, 9, "Invalid control character U+007F."),
+			},
+		},
+		"When scanning a 'string' with control characters, they're reported.": {
+			contentInput: "\"a\x00b\" \"\"\"\n  c\x1B\n  \"\"\"",
+			want: []scanner.Diagnostic{
+				newDiagnostic(scanner.ControlCharacter, 2, 3, "Invalid control character U+0000 in string literal."),
+				newDiagnostic(scanner.ControlCharacter, 13, 14, "Invalid control character U+001B in string literal."),
+			},
+		},
+		"When scanning valid input, nothing is reported.": {
+			contentInput: "version = 1.0 // Comment.\nname: \"lens\"",
+			want:         []scanner.Diagnostic{},
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			scanner := newScanner(tc.contentInput)
+
+			// Act.
+			for scanner.NextToken().Type != token.EOF {
+			}
+
+			got := scanner.Diagnostics()
+
+			// Assert.
+			assert.Equalf(t, len(got), len(tc.want), "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, tc.want, got)
+
+			for idx := range min(len(got), len(tc.want)) {
+				assert.Equalf(t, got[idx], tc.want[idx], "\n\n"+
+					"UT Name:  %s\n"+
+					"\033[32mExpected: #%d - %s\033[0m\n"+
+					"\033[31mActual:   #%d - %s\033[0m\n\n", tcName, idx, tc.want[idx], idx, got[idx])
+			}
+		})
+	}
+}
+
+// UT: Convert a string into a set of lexical tokens that keep the surrounding trivia.
+func TestScanner_NextLossless(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When scanning a token, the trivia around it is attached to it.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := newScanner("  # Comment.\r\na = /* b */ 1 // c\n\nd")
+
+		// Act.
+		wantTokens := []token.Lossless{
+			{
+				Token: newValueToken(token.Ident, "a", 14, 15),
+				Leading: []token.Trivia{
+					newTrivia(token.Whitespace, 0, 2),
+					newTrivia(token.LineComment, 2, 12),
+					newTrivia(token.Newline, 12, 14),
+				},
+				Trailing: []token.Trivia{newTrivia(token.Whitespace, 15, 16)},
+			},
+			{
+				Token: newToken(token.Equals, 16, 17),
+				Trailing: []token.Trivia{
+					newTrivia(token.Whitespace, 17, 18),
+					newTrivia(token.BlockComment, 18, 25),
+					newTrivia(token.Whitespace, 25, 26),
+				},
+			},
+			{
+				Token: newValueToken(token.Integer, "1", 26, 27),
+				Trailing: []token.Trivia{
+					newTrivia(token.Whitespace, 27, 28),
+					newTrivia(token.LineComment, 28, 32),
+					newTrivia(token.Newline, 32, 33),
+				},
+			},
+			{
+				Token:   newValueToken(token.Ident, "d", 34, 35),
+				Leading: []token.Trivia{newTrivia(token.Newline, 33, 34)},
+			},
+			{
+				Token: newToken(token.EOF, 35, 35),
+			},
+		}
+
+		for idx, want := range wantTokens {
+			got := scanner.NextLossless()
+
+			// Assert.
+			assert.Equalf(t, got.Token, want.Token, "\n\n"+
+				"UT Name:  When scanning a token, the trivia around it is attached to it.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want.Token, idx, got.Token)
+
+			gotTrivia, wantTrivia := fmt.Sprint(got.Leading, got.Trailing), fmt.Sprint(want.Leading, want.Trailing)
+
+			assert.Equalf(t, gotTrivia, wantTrivia, "\n\n"+
+				"UT Name:  When scanning a token, the trivia around it is attached to it.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, wantTrivia, idx, gotTrivia)
+		}
+	})
+
+	t.Run("When scanning tokens with their trivia, the input is reproduced exactly.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		input := &text.Input{
+			Content: "/* Header. /* Nested. */ */\r\nversion = 1.0 # Version.\n\textension: \".cs\" {\r\t}\n@\x00 /* Open.",
+		}
+		scanner := scanner.New(input)
+
+		// Act.
+		var sb strings.Builder
+
+		for {
+			tok := scanner.NextLossless()
+
+			for _, tr := range tok.Leading {
+				sb.WriteString(tr.Span.Slice(input))
+			}
+
+			sb.WriteString(tok.Span.Slice(input))
+
+			for _, tr := range tok.Trailing {
+				sb.WriteString(tr.Span.Slice(input))
+			}
+
+			if tok.Type == token.EOF {
+				break
+			}
+		}
+
+		// Assert.
+		assert.Equalf(t, sb.String(), input.Content, "\n\n"+
+			"UT Name:  When scanning tokens with their trivia, the input is reproduced exactly.\n"+
+			"\033[32mExpected: %q\033[0m\n"+
+			"\033[31mActual:   %q\033[0m\n\n", input.Content, sb.String())
+	})
+}
+
+// UT: Convert the content of a file into a set of lexical tokens that carry their position.
+func TestNewFile(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	fs := text.NewFileSet()
+	fs.AddFile("a.lux", &text.Input{Content: "enabled"})
+	file := fs.AddFile("b.lux", &text.Input{Content: "enabled: true"})
+	scanner := scanner.NewFile(file)
+
+	// Act.
+	wantPositions := []string{"b.lux:1:1", "b.lux:1:8", "b.lux:1:10", "b.lux:1:14"}
+
+	for idx, want := range wantPositions {
+		got := fs.Position(scanner.NextToken().Pos).String()
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When scanning a file, every token carries its position in the file set.\n"+
+			"\033[32mExpected: #%d - %s\033[0m\n"+
+			"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+	}
+}
+
+// UT: Verify that the diagnostics of a file carry their position.
+func TestNewFile_Diagnostics(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	fs := text.NewFileSet()
+	fs.AddFile("a.lux", &text.Input{Content: "enabled"})
+	file := fs.AddFile("b.lux", &text.Input{Content: "enabled: true\nname: @"})
+	scanner := scanner.NewFile(file)
+
+	// Act.
+	for scanner.NextToken().Type != token.EOF {
+	}
+
+	got, want := fs.Position(scanner.Diagnostics()[0].Pos).String(), "b.lux:2:7"
+
+	// Assert.
+	assert.Equalf(t, got, want, "\n\n"+
+		"UT Name:  When scanning a file, every diagnostic carries its position in the file set.\n"+
+		"\033[32mExpected: %s\033[0m\n"+
+		"\033[31mActual:   %s\033[0m\n\n", want, got)
+}
+
+// UT: Convert the data of a reader into a set of lexical tokens.
+func TestNewReader(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	input := "version = 1.0 // Comment.\n" +
+		"name: \"l\\u{e9}ns \\q\" /* Nested /* comment */ */ r\"C:\\\" 0x_1F 1e-3 $%^ \xFF\n" +
+		"description: \"\"\"\n    Détails.\n      « Indented »\n    \"\"\"\n" +
+		"unclosed: \"abc\n" +
+		"  🦀: 日本語 # Trailing comment."
+
+	for tcName, tc := range map[string]struct {
+		readerInput io.Reader
+		sizeInput   int
+	}{
+		"When reading 1 byte at a time, the tokens are the same as when scanning the whole input.": {
+			readerInput: iotest.OneByteReader(strings.NewReader(input)),
+			sizeInput:   scanner.DefaultChunkSize,
+		},
+		"When reading in small chunks, the tokens are the same as when scanning the whole input.": {
+			readerInput: strings.NewReader(input),
+			sizeInput:   3,
+		},
+		"When reading in large chunks, the tokens are the same as when scanning the whole input.": {
+			readerInput: strings.NewReader(input),
+			sizeInput:   scanner.DefaultChunkSize,
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			want := newScanner(input)
+			got := scanner.NewReaderSize(tc.readerInput, tc.sizeInput)
+
+			// Act & Assert.
+			for idx := 0; ; idx++ {
+				wantToken, gotToken := want.NextToken(), got.NextToken()
+
+				assert.Equalf(t, gotToken, wantToken, "\n\n"+
+					"UT Name:  %s\n"+
+					"\033[32mExpected: #%d - %s\033[0m\n"+
+					"\033[31mActual:   #%d - %s\033[0m\n\n", tcName, idx, wantToken, idx, gotToken)
+
+				if wantToken.Type == token.EOF {
+					break
+				}
+			}
+
+			assert.Equalf(t, fmt.Sprint(got.Diagnostics()), fmt.Sprint(want.Diagnostics()), "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, want.Diagnostics(), got.Diagnostics())
+		})
+	}
+
+	t.Run("When reading a large input, the spans are offsets from the start of the input.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		scanner := scanner.NewReader(strings.NewReader(strings.Repeat("key = 1_000 // Comment.\n", 10_000) + "end"))
+
+		// Act.
+		var got token.Token
+
+		for tok := range scanner.All() {
+			got = tok
+		}
+
+		want := newValueToken(token.Ident, "end", 240_000, 240_003)
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When reading a large input, the spans are offsets from the start of the input.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, got)
+	})
+
+	t.Run("When the reader fails, the input ends and the error is reported.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		errRead := errors.New("read failed")
+		scanner := scanner.NewReader(io.MultiReader(strings.NewReader("key = value"), iotest.ErrReader(errRead)))
+		wantTokens := newTokenSet(
+			newValueToken(token.Ident, "key", 0, 3),
+			newToken(token.Equals, 4, 5),
+			newValueToken(token.Ident, "value", 6, 11),
+			newToken(token.EOF, 11, 11),
+		)
+
+		// Act & Assert.
+		for idx, want := range wantTokens {
+			got := scanner.NextToken()
+
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When the reader fails, the input ends and the error is reported.\n"+
+				"\033[32mExpected: #%d - %s\033[0m\n"+
+				"\033[31mActual:   #%d - %s\033[0m\n\n", idx, want, idx, got)
+		}
+
+		assert.Equalf(t, scanner.Err(), errRead, "\n\n"+
+			"UT Name:  When the reader fails, the input ends and the error is reported.\n"+
+			"\033[32mExpected: %v\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", errRead, scanner.Err())
+	})
+
+	t.Run("When the reader returns no data, the input ends and the lack of progress is reported.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		reader := &emptyReader{}
+		scanner := scanner.NewReader(io.MultiReader(strings.NewReader("key"), reader))
+		want := newValueToken(token.Ident, "key", 0, 3)
+
+		// Act.
+		got := scanner.NextToken()
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When the reader returns no data, the input ends and the lack of progress is reported.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, got)
+
+		assert.Equalf(t, scanner.NextToken().Type, token.EOF, "\n\n"+
+			"UT Name:  When the reader returns no data, the input ends and the lack of progress is reported.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", token.EOF, scanner.NextToken().Type)
+
+		assert.Equalf(t, scanner.Err(), io.ErrNoProgress, "\n\n"+
+			"UT Name:  When the reader returns no data, the input ends and the lack of progress is reported.\n"+
+			"\033[32mExpected: %v\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", io.ErrNoProgress, scanner.Err())
+
+		assert.Equalf(t, reader.reads, 100, "\n\n"+
+			"UT Name:  When the reader returns no data, the input ends and the lack of progress is reported.\n"+
+			"\033[32mExpected: %d reads\033[0m\n"+
+			"\033[31mActual:   %d reads\033[0m\n\n", 100, reader.reads)
+	})
+}
+
+// UT: Verify that reading a long comment doesn't keep and copy the whole comment for every chunk.
+func TestNewReader_Memory(t *testing.T) {
+	// No parallel execution, because allocations can't be counted while other tests are running.
+
+	// Arrange.
+	const size = 4 << 20
+
+	allocated := func(content string) uint64 {
+		var before, after runtime.MemStats
+
+		runtime.ReadMemStats(&before)
+
+		scanner := scanner.NewReaderSize(strings.NewReader(content), 4096)
+
+		for scanner.NextToken().Type != token.EOF {
+		}
+
+		runtime.ReadMemStats(&after)
+
+		return after.TotalAlloc - before.TotalAlloc
+	}
+
+	for tcName, tc := range map[string]struct {
+		contentInput string
+	}{
+		"When reading a long line comment, the allocated memory grows linearly.": {
+			contentInput: "// " + strings.Repeat("a", size) + "\nend",
+		},
+		"When reading a long block comment, the allocated memory grows linearly.": {
+			contentInput: "/* " + strings.Repeat("a", size) + " */ end",
+		},
+	} {
+		// Act.
+		got := allocated(tc.contentInput)
+
+		// Assert.
+		assert.Equalf(t, got < 2*size, true, "\n\n"+
+			"UT Name:  %s\n"+
+			"\033[32mExpected: < %d bytes\033[0m\n"+
+			"\033[31mActual:   %d bytes\033[0m\n\n", tcName, 2*size, got)
+	}
+}
+
 // Returns a new input with the given content.
 func newScanner(content string) *scanner.Scanner {
 	input := &text.Input{
@@ -640,6 +1595,16 @@ func newScanner(content string) *scanner.Scanner {
 	return scanner.New(input)
 }
 
+// Returns a new diagnostic with the given code, span and message, and the "Error" severity.
+func newDiagnostic(code scanner.Code, start, end int, msg string) scanner.Diagnostic {
+	return scanner.Diagnostic{
+		Code:     code,
+		Severity: scanner.Error,
+		Span:     newSpan(start, end),
+		Message:  msg,
+	}
+}
+
 // Returns a new set of tokens.
 func newTokenSet(toks ...token.Token) []token.Token {
 	return toks
@@ -662,6 +1627,14 @@ func newValueToken(tType token.Type, value string, start, end int) token.Token {
 	}
 }
 
+// Returns a new piece of trivia with the given kind and span.
+func newTrivia(kind token.TriviaKind, start, end int) token.Trivia {
+	return token.Trivia{
+		Kind: kind,
+		Span: newSpan(start, end),
+	}
+}
+
 // Returns a new span with the given start and end positions.
 func newSpan(start, end int) text.Span {
 	return text.Span{
@@ -669,3 +1642,15 @@ func newSpan(start, end int) text.Span {
 		End:   end,
 	}
 }
+
+// A reader that never returns any data, nor an error.
+type emptyReader struct {
+	reads int
+}
+
+// Read counts the number of times it's called, and returns nothing.
+func (r *emptyReader) Read([]byte) (int, error) {
+	r.reads++
+
+	return 0, nil
+}
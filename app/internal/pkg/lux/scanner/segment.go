@@ -0,0 +1,140 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package scanner implements a scanner for the lux language.
+package scanner
+
+import (
+	"strings"
+
+	"github.com/kdeconinck/lens/internal/pkg/lux/token"
+	"github.com/kdeconinck/lens/internal/pkg/text"
+)
+
+// Segment maps a part of the value of a string literal to the part of the source that it's taken from.
+//
+// The value of a segment is either a copy of its source, in which case both have the same length, or the value of a
+// single escape sequence.
+type Segment struct {
+	// Value is the part of the value of the string literal.
+	Value text.Span
+
+	// Source is the part of the input that the value is taken from.
+	Source text.Span
+}
+
+// Segments returns the segments of the value of the string literal tok, which is scanned from input, in the order of
+// the value. The indentation that's removed from a multi-line string, and its quotes, aren't part of any segment.
+//
+// Returns nil if tok isn't a string literal.
+func Segments(input *text.Input, tok token.Token) []Segment {
+	if tok.Type != token.String {
+		return nil
+	}
+
+	scanner := New(input)
+	scanner.pos, scanner.tokenStart = tok.Span.Start, tok.Span.Start
+	scanner.mapping = true
+
+	if scanner.scanToken().Type != token.String {
+		return nil
+	}
+
+	return scanner.segments
+}
+
+// SourceOffset returns the offset in input of the byte at offset in the value of the string literal tok, which is
+// scanned from input, see [Segments]. A byte in the value of an escape sequence maps to the start of the escape
+// sequence, and the end of the value maps to the end of the source of the last segment. The value of an empty string
+// maps to the offset right after its opening quotes.
+//
+// Returns the start of tok if it isn't a string literal.
+func SourceOffset(input *text.Input, tok token.Token, offset int) int {
+	if tok.Type != token.String {
+		return tok.Span.Start
+	}
+
+	segments := Segments(input, tok)
+
+	if len(segments) == 0 {
+		return contentStart(input, tok)
+	}
+
+	for _, segment := range segments {
+		if offset >= segment.Value.End {
+			continue
+		}
+
+		if segment.Value.Len() == segment.Source.Len() {
+			return segment.Source.Start + max(offset-segment.Value.Start, 0)
+		}
+
+		return segment.Source.Start
+	}
+
+	return segments[len(segments)-1].Source.End
+}
+
+// Returns the offset in input of the content of the string literal tok, which follows its opening quotes.
+func contentStart(input *text.Input, tok token.Token) int {
+	source := input.Content[tok.Span.Start:tok.Span.End]
+	start := tok.Span.Start
+
+	if strings.HasPrefix(source, "r") {
+		source, start = source[1:], start+1
+	}
+
+	if strings.HasPrefix(source, `"""`) {
+		return start + 3
+	}
+
+	return min(start+1, tok.Span.End)
+}
+
+// Add the segment of the value of the string literal that's being scanned between valueStart and valueEnd, which is
+// taken from the data between start and end. A copy that directly follows another copy extends it.
+func (scanner *Scanner) addSegment(valueStart, valueEnd, start, end int) {
+	if valueStart == valueEnd && start == end {
+		return
+	}
+
+	segment := Segment{
+		Value:  text.Span{Start: valueStart, End: valueEnd},
+		Source: text.Span{Start: start, End: end},
+	}
+
+	if last := len(scanner.segments) - 1; last >= 0 {
+		prev := &scanner.segments[last]
+
+		if prev.Value.End == valueStart && prev.Source.End == start &&
+			prev.Value.Len() == prev.Source.Len() && segment.Value.Len() == segment.Source.Len() {
+			prev.Value.End, prev.Source.End = valueEnd, end
+
+			return
+		}
+	}
+
+	scanner.segments = append(scanner.segments, segment)
+}
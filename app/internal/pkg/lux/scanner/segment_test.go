@@ -0,0 +1,205 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify the implementation of the "scanner" package.
+package scanner_test
+
+import (
+	"testing"
+
+	"github.com/kdeconinck/lens/internal/pkg/assert"
+	"github.com/kdeconinck/lens/internal/pkg/lux/scanner"
+	"github.com/kdeconinck/lens/internal/pkg/lux/token"
+	"github.com/kdeconinck/lens/internal/pkg/text"
+)
+
+// A dedented multi-line sample with escaped quotes, which has the value "if x {\n  say(\"hi\")\n}".
+const sample = "sample: \"\"\"\n    if x {\n      say(\\\"hi\\\")\n    }\n    \"\"\""
+
+// UT: Map the value of a string literal to the source it's taken from.
+func TestSegments(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		contentInput string
+		indexInput   int
+		want         []scanner.Segment
+	}{
+		"When the string has no escape sequences, its value is a single copy of its content.": {
+			contentInput: "name: \"abc\"",
+			indexInput:   2,
+			want:         []scanner.Segment{newSegment(0, 3, 7, 10)},
+		},
+		"When the string is raw, backslashes are part of the copy.": {
+			contentInput: "name: r\"a\\b\"",
+			indexInput:   2,
+			want:         []scanner.Segment{newSegment(0, 3, 8, 11)},
+		},
+		"When the string has an escape sequence, it's a segment of its own.": {
+			contentInput: "name: \"a\\nb\"",
+			indexInput:   2,
+			want:         []scanner.Segment{newSegment(0, 1, 7, 8), newSegment(1, 2, 8, 10), newSegment(2, 3, 10, 11)},
+		},
+		"When a multi-line string is dedented, the removed indentation isn't part of any segment.": {
+			contentInput: sample,
+			indexInput:   2,
+			want: []scanner.Segment{
+				newSegment(0, 7, 16, 23),
+				newSegment(7, 13, 27, 33),
+				newSegment(13, 14, 33, 35),
+				newSegment(14, 16, 35, 37),
+				newSegment(16, 17, 37, 39),
+				newSegment(17, 19, 39, 41),
+				newSegment(19, 20, 45, 46),
+			},
+		},
+		"When the token isn't a string, there are no segments.": {
+			contentInput: "name: \"abc\"",
+			indexInput:   0,
+			want:         nil,
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			input := &text.Input{Content: tc.contentInput}
+			tok := nthToken(tc.contentInput, tc.indexInput)
+
+			// Act.
+			got := scanner.Segments(input, tok)
+
+			// Assert.
+			assert.Equalf(t, len(got), len(tc.want), "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, tc.want, got)
+
+			for idx := range min(len(got), len(tc.want)) {
+				assert.Equalf(t, got[idx], tc.want[idx], "\n\n"+
+					"UT Name:  %s\n"+
+					"\033[32mExpected: #%d - %v\033[0m\n"+
+					"\033[31mActual:   #%d - %v\033[0m\n\n", tcName, idx, tc.want[idx], idx, got[idx])
+			}
+		})
+	}
+}
+
+// UT: Map an offset in the value of a string literal to an offset in its source.
+func TestSourceOffset(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		contentInput string
+		indexInput   int
+		offsetInput  int
+		want         int
+	}{
+		"When the offset is at the start of the value, it maps to the first character after the indentation.": {
+			contentInput: sample,
+			indexInput:   2,
+			offsetInput:  0,
+			want:         16,
+		},
+		"When the offset is in an indented line, it maps past the removed indentation.": {
+			contentInput: sample,
+			indexInput:   2,
+			offsetInput:  9,
+			want:         29,
+		},
+		"When the offset is in the value of an escape sequence, it maps to the start of the escape sequence.": {
+			contentInput: sample,
+			indexInput:   2,
+			offsetInput:  13,
+			want:         33,
+		},
+		"When the offset follows an escape sequence, it maps past the escape sequence.": {
+			contentInput: sample,
+			indexInput:   2,
+			offsetInput:  15,
+			want:         36,
+		},
+		"When the offset is at the end of the value, it maps to the end of the last segment.": {
+			contentInput: sample,
+			indexInput:   2,
+			offsetInput:  20,
+			want:         46,
+		},
+		"When the string is empty, it maps right after the opening quote.": {
+			contentInput: "name: \"\"",
+			indexInput:   2,
+			offsetInput:  0,
+			want:         7,
+		},
+		"When the raw multi-line string is empty, it maps right after the opening quotes.": {
+			contentInput: "name: r\"\"\"\"\"\"",
+			indexInput:   2,
+			offsetInput:  0,
+			want:         10,
+		},
+		"When the token isn't a string, it maps to the start of the token.": {
+			contentInput: "name: \"abc\"",
+			indexInput:   0,
+			offsetInput:  2,
+			want:         0,
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			input := &text.Input{Content: tc.contentInput}
+			tok := nthToken(tc.contentInput, tc.indexInput)
+
+			// Act.
+			got := scanner.SourceOffset(input, tok, tc.offsetInput)
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d\033[0m\n"+
+				"\033[31mActual:   %d\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// Returns the token at the given index in the tokens of content.
+func nthToken(content string, index int) token.Token {
+	scanner := newScanner(content)
+
+	for range index {
+		scanner.NextToken()
+	}
+
+	return scanner.NextToken()
+}
+
+// Returns a new segment that maps the value between valueStart and valueEnd to the source between start and end.
+func newSegment(valueStart, valueEnd, start, end int) scanner.Segment {
+	return scanner.Segment{
+		Value:  newSpan(valueStart, valueEnd),
+		Source: newSpan(start, end),
+	}
+}
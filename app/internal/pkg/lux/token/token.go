@@ -37,11 +37,17 @@ type Token struct {
 	// Type is the classification of the token.
 	Type Type
 
-	// Literal is the raw text string represented by the token.
+	// Literal is the value of the token. For a string literal, that's its decoded value: escape sequences are decoded
+	// and the common indentation of a multi-line string is removed. The raw text of a token is the part of the source
+	// covered by Span, and scanner.Segments maps the value of a string literal back to it.
 	Literal string
 
 	// Span is the exact location of the token in the source it was read from.
 	Span text.Span
+
+	// Pos is the position of the first byte of the token in the [text.FileSet] of the file it was read from, or
+	// [text.NoPos] if the source isn't part of a file set.
+	Pos text.Pos
 }
 
 // String returns the string representation of the token.
@@ -42,10 +42,10 @@ func Test_TokenString(t *testing.T) {
 		t.Parallel() // Enable parallel execution.
 
 		// Arrange.
-		tok := newValueToken(token.Number, "123", 0, 3)
+		tok := newValueToken(token.Integer, "123", 0, 3)
 
 		// Act.
-		got, want := tok.String(), "[0..3] 'Number' with value \"123\"."
+		got, want := tok.String(), "[0..3] 'Integer' with value \"123\"."
 
 		// Assert.
 		assert.Equalf(t, got, want, "\n\n"+
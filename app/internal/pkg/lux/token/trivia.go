@@ -0,0 +1,96 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package token defines the lexical atoms of the lux language.
+package token
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kdeconinck/lens/internal/pkg/text"
+)
+
+// TriviaKind represents the category of a piece of trivia.
+type TriviaKind int
+
+// The different kinds of trivia.
+const (
+	Whitespace TriviaKind = iota
+	Newline
+	LineComment
+	BlockComment
+	Skipped
+)
+
+// Maps a [TriviaKind] to its human-readable name.
+var triviaMap = map[TriviaKind]string{
+	Whitespace:   "Whitespace",
+	Newline:      "Newline",
+	LineComment:  "LineComment",
+	BlockComment: "BlockComment",
+	Skipped:      "Skipped",
+}
+
+// String returns the string representation of the trivia kind.
+func (k TriviaKind) String() string {
+	value, ok := triviaMap[k]
+
+	if ok {
+		return value
+	}
+
+	return "Unknown(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Trivia represents a part of the lux source that doesn't affect its meaning, such as whitespace or a comment.
+//
+// Text that can't be scanned as a token, such as an invalid character, is kept as "Skipped" trivia.
+type Trivia struct {
+	// Kind is the classification of the trivia.
+	Kind TriviaKind
+
+	// Span is the exact location of the trivia in the source it was read from.
+	Span text.Span
+}
+
+// String returns the string representation of the trivia.
+func (tr Trivia) String() string {
+	return fmt.Sprintf("[%s] '%s'.", tr.Span, tr.Kind)
+}
+
+// Lossless is a [Token] together with the trivia that surrounds it.
+//
+// Writing the leading trivia, the token and the trailing trivia of every token in a stream, in that order, reproduces
+// the source exactly.
+type Lossless struct {
+	Token
+
+	// Leading is the trivia between the trailing trivia of the previous token and this token.
+	Leading []Trivia
+
+	// Trailing is the trivia after this token, up to and including the first line break.
+	Trailing []Trivia
+}
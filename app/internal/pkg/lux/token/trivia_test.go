@@ -0,0 +1,99 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify the implementation of the "token" package.
+package token_test
+
+import (
+	"testing"
+
+	"github.com/kdeconinck/lens/internal/pkg/assert"
+	"github.com/kdeconinck/lens/internal/pkg/lux/token"
+)
+
+// UT: Get the human-readable representation of a kind of trivia.
+func Test_TriviaKindString(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		kindInput token.TriviaKind
+		want      string
+	}{
+		"When the trivia is 'Whitespace' it's displayed as 'Whitespace'.": {
+			kindInput: token.Whitespace,
+			want:      "Whitespace",
+		},
+		"When the trivia is 'Newline' it's displayed as 'Newline'.": {
+			kindInput: token.Newline,
+			want:      "Newline",
+		},
+		"When the trivia is 'LineComment' it's displayed as 'LineComment'.": {
+			kindInput: token.LineComment,
+			want:      "LineComment",
+		},
+		"When the trivia is 'BlockComment' it's displayed as 'BlockComment'.": {
+			kindInput: token.BlockComment,
+			want:      "BlockComment",
+		},
+		"When the trivia is 'Skipped' it's displayed as 'Skipped'.": {
+			kindInput: token.Skipped,
+			want:      "Skipped",
+		},
+		"When the trivia is NOT known it's displayed as 'Unknown(xxx)'.": {
+			kindInput: token.TriviaKind(100),
+			want:      "Unknown(100)",
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := tc.kindInput.String()
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s.\n"+
+				"\033[32mExpected: %s.\033[0m\n"+
+				"\033[31mActual:   %s.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Get the human-readable representation of a piece of trivia.
+func Test_TriviaString(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	tr := token.Trivia{Kind: token.LineComment, Span: newSpan(4, 12)}
+
+	// Act.
+	got, want := tr.String(), "[4..12] 'LineComment'."
+
+	// Assert.
+	assert.Equalf(t, got, want, "\n\n"+
+		"UT Name:  When formatting 'Trivia', the representation contains its span and kind.\n"+
+		"\033[32mExpected: %s\033[0m\n"+
+		"\033[31mActual:   %s\033[0m\n\n", want, got)
+}
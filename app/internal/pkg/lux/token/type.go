@@ -33,10 +33,10 @@ type Type int
 
 // The different types of a lexical token.
 const (
-	Error Type = iota
-	EOF
+	EOF Type = iota
 	Ident
-	Number
+	Integer
+	Float
 	String
 	Bool
 	Dot
@@ -51,10 +51,10 @@ const (
 
 // Maps a [Type] to its human-readable name.
 var tokenMap = map[Type]string{
-	Error:    "Error",
 	EOF:      "EOF",
 	Ident:    "Identifier",
-	Number:   "Number",
+	Integer:  "Integer",
+	Float:    "Float",
 	String:   "String",
 	Bool:     "Boolean",
 	Dot:      ".",
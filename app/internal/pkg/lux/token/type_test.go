@@ -41,10 +41,6 @@ func Test_TypeString(t *testing.T) {
 		typeInput token.Type
 		want      string
 	}{
-		"When the token is 'Error' it's displayed as 'Error'.": {
-			typeInput: token.Error,
-			want:      "Error",
-		},
 		"When the token is 'EOF' it's displayed as 'EOF'.": {
 			typeInput: token.EOF,
 			want:      "EOF",
@@ -53,9 +49,13 @@ func Test_TypeString(t *testing.T) {
 			typeInput: token.Ident,
 			want:      "Identifier",
 		},
-		"When the token is 'Number' it's displayed as 'Number'.": {
-			typeInput: token.Number,
-			want:      "Number",
+		"When the token is 'Integer' it's displayed as 'Integer'.": {
+			typeInput: token.Integer,
+			want:      "Integer",
+		},
+		"When the token is 'Float' it's displayed as 'Float'.": {
+			typeInput: token.Float,
+			want:      "Float",
 		},
 		"When the token is 'String' it's displayed as 'String'.": {
 			typeInput: token.String,
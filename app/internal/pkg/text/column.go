@@ -0,0 +1,117 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package text provides foundational types for managing and measuring text content.
+//
+// It serves as a coordinate system for UTF-8 encoded strings, defining how raw byte segments [Span]s map to
+// human-readable dimensions like line and columns.
+package text
+
+import "unicode/utf8"
+
+// DefaultTabWidth is the distance between 2 tab stops when [Columns.TabWidth] isn't set.
+const DefaultTabWidth = 4
+
+// Unit represents the quantity in which the column of a [Location] is measured.
+type Unit int
+
+// The different units in which a column can be measured.
+const (
+	// Runes counts every UTF-8 encoded character as a single column.
+	Runes Unit = iota
+
+	// Bytes counts every byte of the UTF-8 encoding as a single column.
+	Bytes
+
+	// UTF16 counts UTF-16 code units, so characters outside the Basic Multilingual Plane take 2 columns.
+	UTF16
+
+	// Display counts the cells a grapheme cluster occupies in a terminal. Wide and fullwidth East Asian characters and
+	// emoji take 2 columns, combining marks and other zero-width characters are folded into the character they belong
+	// to, and tabs are expanded to the next tab stop.
+	Display
+)
+
+// Columns describes how the column of a [Location] is measured.
+//
+// The zero value measures columns in [Runes], which is what [Input.LineCol] and [Input.Offset] use.
+type Columns struct {
+	// Unit is the quantity in which the column is measured.
+	Unit Unit
+
+	// TabWidth is the distance between 2 tab stops, used by [Display]. A value <= 0 selects [DefaultTabWidth].
+	TabWidth int
+}
+
+// Width returns the number of columns that s occupies when it starts at column 1.
+//
+// The content of s is treated as a single line; line breaks are measured like any other character.
+func (cols Columns) Width(s string) int {
+	column := 1
+
+	for len(s) > 0 {
+		size, width := cols.next(s, column)
+		column += width
+		s = s[size:]
+	}
+
+	return column - 1
+}
+
+// Returns the size in bytes and the width in columns of the first character of s, which starts at the given 1-based
+// column.
+//
+// For [Display], the first character is the whole grapheme cluster at the start of s, so that combining marks and emoji
+// sequences are never split.
+func (cols Columns) next(s string, column int) (size, width int) {
+	r, size := utf8.DecodeRuneInString(s)
+
+	switch cols.Unit {
+	case Bytes:
+		return size, size
+
+	case UTF16:
+		if r > 0xFFFF {
+			return size, 2
+		}
+
+	case Display:
+		if r == '\t' {
+			tabWidth := cols.TabWidth
+
+			if tabWidth <= 0 {
+				tabWidth = DefaultTabWidth
+			}
+
+			return size, tabWidth - (column-1)%tabWidth
+		}
+
+		size = graphemeLen(s)
+
+		return size, clusterWidth(s[:size])
+	}
+
+	return size, 1
+}
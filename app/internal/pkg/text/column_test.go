@@ -0,0 +1,188 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify the implementation of the "text" package.
+package text_test
+
+import (
+	"testing"
+
+	"github.com/kdeconinck/lens/internal/pkg/assert"
+	"github.com/kdeconinck/lens/internal/pkg/text"
+)
+
+// UT: Verify that byte offsets are translated into locations measured in the requested unit.
+func TestInput_LocationOf(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		contentInput string
+		offsetInput  int
+		colsInput    text.Columns
+		want         text.Location
+	}{
+		"When measuring in runes, an astral-plane character counts as 1 column.": {
+			contentInput: "A🚀C",
+			offsetInput:  5,
+			colsInput:    text.Columns{Unit: text.Runes},
+			want:         newLocation(1, 3),
+		},
+		"When measuring in bytes, an astral-plane character counts as 4 columns.": {
+			contentInput: "A🚀C",
+			offsetInput:  5,
+			colsInput:    text.Columns{Unit: text.Bytes},
+			want:         newLocation(1, 6),
+		},
+		"When measuring in UTF-16 code units, an astral-plane character counts as 2 columns.": {
+			contentInput: "A🚀C",
+			offsetInput:  5,
+			colsInput:    text.Columns{Unit: text.UTF16},
+			want:         newLocation(1, 4),
+		},
+		"When measuring in UTF-16 code units, a BMP character counts as 1 column.": {
+			contentInput: "Aé€C",
+			offsetInput:  6,
+			colsInput:    text.Columns{Unit: text.UTF16},
+			want:         newLocation(1, 4),
+		},
+		"When measuring display columns, a tab expands to the next tab stop.": {
+			contentInput: "\tA",
+			offsetInput:  1,
+			colsInput:    text.Columns{Unit: text.Display, TabWidth: 8},
+			want:         newLocation(1, 9),
+		},
+		"When measuring display columns, a tab after text only fills up to the next tab stop.": {
+			contentInput: "AB\tC",
+			offsetInput:  3,
+			colsInput:    text.Columns{Unit: text.Display, TabWidth: 4},
+			want:         newLocation(1, 5),
+		},
+		"When measuring display columns without a tab width, the default tab width is used.": {
+			contentInput: "\t\tA",
+			offsetInput:  2,
+			colsInput:    text.Columns{Unit: text.Display},
+			want:         newLocation(1, 2*text.DefaultTabWidth+1),
+		},
+		"When measuring display columns on a later line, the tab stops restart.": {
+			contentInput: "ABC\n\tD",
+			offsetInput:  5,
+			colsInput:    text.Columns{Unit: text.Display, TabWidth: 4},
+			want:         newLocation(2, 5),
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			input := newInput(tc.contentInput)
+
+			// Act.
+			got := input.LocationOf(tc.offsetInput, tc.colsInput)
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Verify that locations measured in the requested unit are translated back into byte offsets.
+func TestInput_OffsetOf(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		contentInput string
+		locInput     text.Location
+		colsInput    text.Columns
+		want         int
+		wantErr      bool
+	}{
+		"When measuring in bytes, the column maps directly onto the offset.": {
+			contentInput: "A🚀C",
+			locInput:     newLocation(1, 6),
+			colsInput:    text.Columns{Unit: text.Bytes},
+			want:         5,
+		},
+		"When measuring in bytes, a column inside a multi-byte character is rejected.": {
+			contentInput: "A🚀C",
+			locInput:     newLocation(1, 3),
+			colsInput:    text.Columns{Unit: text.Bytes},
+			wantErr:      true,
+		},
+		"When measuring in UTF-16 code units, the column after a surrogate pair maps onto the next character.": {
+			contentInput: "A🚀C",
+			locInput:     newLocation(1, 4),
+			colsInput:    text.Columns{Unit: text.UTF16},
+			want:         5,
+		},
+		"When measuring in UTF-16 code units, a column between the halves of a surrogate pair is rejected.": {
+			contentInput: "A🚀C",
+			locInput:     newLocation(1, 3),
+			colsInput:    text.Columns{Unit: text.UTF16},
+			wantErr:      true,
+		},
+		"When measuring in UTF-16 code units, the column after the last character maps onto the end.": {
+			contentInput: "A🚀C",
+			locInput:     newLocation(1, 5),
+			colsInput:    text.Columns{Unit: text.UTF16},
+			want:         6,
+		},
+		"When measuring display columns, the column after a tab maps onto the next character.": {
+			contentInput: "AB\tC",
+			locInput:     newLocation(1, 5),
+			colsInput:    text.Columns{Unit: text.Display, TabWidth: 4},
+			want:         3,
+		},
+		"When measuring display columns, a column inside an expanded tab is rejected.": {
+			contentInput: "AB\tC",
+			locInput:     newLocation(1, 4),
+			colsInput:    text.Columns{Unit: text.Display, TabWidth: 4},
+			wantErr:      true,
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			input := newInput(tc.contentInput)
+
+			// Act.
+			got, err := input.OffsetOf(tc.locInput, tc.colsInput)
+
+			// Assert.
+			assert.Equalf(t, err != nil, tc.wantErr, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: error = %t\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, tc.wantErr, err)
+
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d\033[0m\n"+
+				"\033[31mActual:   %d\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
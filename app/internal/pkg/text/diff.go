@@ -0,0 +1,476 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package text provides foundational types for managing and measuring text content.
+//
+// It serves as a coordinate system for UTF-8 encoded strings, defining how raw byte segments [Span]s map to
+// human-readable dimensions like line and columns.
+package text
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DefaultContext is the number of unchanged lines that are commonly shown around a change, such as by "diff -u".
+const DefaultContext = 3
+
+// The marker that follows the last line of an input that doesn't end with a line break.
+const noNewlineMarker = "\\ No newline at end of file\n"
+
+// Op represents what happened to a line when comparing 2 inputs.
+type Op int
+
+// The different things that can happen to a line.
+const (
+	// Equal is used for a line that's present in both inputs.
+	Equal Op = iota
+
+	// Delete is used for a line that's only present in the old input.
+	Delete
+
+	// Insert is used for a line that's only present in the new input.
+	Insert
+)
+
+// Maps an [Op] to its human-readable name.
+var opMap = map[Op]string{
+	Equal:  "Equal",
+	Delete: "Delete",
+	Insert: "Insert",
+}
+
+// Maps an [Op] to the character that starts a line of that kind in a unified diff.
+var opPrefix = map[Op]string{
+	Equal:  " ",
+	Delete: "-",
+	Insert: "+",
+}
+
+// String returns the string representation of the operation.
+func (op Op) String() string {
+	value, ok := opMap[op]
+
+	if ok {
+		return value
+	}
+
+	return "Unknown(" + strconv.Itoa(int(op)) + ")"
+}
+
+// DiffLine is a line that's part of a [Hunk].
+type DiffLine struct {
+	// Op tells whether the line is unchanged, deleted or inserted.
+	Op Op
+
+	// Text is the content of the line, including its line break. Only the last line of an input can lack one.
+	Text string
+}
+
+// Hunk is a group of changes that are close enough to each other to be shown together, surrounded by unchanged lines.
+type Hunk struct {
+	// OldStart is the 1-based number of the first line of the hunk in the old input.
+	OldStart int
+
+	// OldLines is the number of lines of the hunk in the old input.
+	OldLines int
+
+	// NewStart is the 1-based number of the first line of the hunk in the new input.
+	NewStart int
+
+	// NewLines is the number of lines of the hunk in the new input.
+	NewLines int
+
+	// Lines are the lines of the hunk, with the deleted lines of every change before its inserted lines.
+	Lines []DiffLine
+}
+
+// Header returns the line that introduces the hunk in a unified diff, such as "@@ -1,3 +1,4 @@".
+//
+// As in the output of "diff -u", a side without lines refers to the line before the hunk, and a count of 1 is omitted.
+func (hunk Hunk) Header() string {
+	return "@@ -" + hunkRange(hunk.OldStart, hunk.OldLines) + " +" + hunkRange(hunk.NewStart, hunk.NewLines) + " @@"
+}
+
+// Differ compares 2 inputs line by line, using the algorithm described in "An O(ND) Difference Algorithm and Its
+// Variations" by Eugene W. Myers.
+//
+// The output of [Differ.Write] is a unified diff, which looks like this:
+//
+//	--- rules.lux
+//	+++ rules.lux (formatted)
+//	@@ -1,2 +1,2 @@
+//	-version=1.0
+//	+version = 1.0
+//	 name = "lens"
+type Differ struct {
+	// Context is the number of unchanged lines that are shown before and after every change. Changes that are separated
+	// by at most twice this number of lines share a hunk. See [DefaultContext].
+	Context int
+
+	// ShowWhitespace replaces spaces by '·' and tabs by '→' on deleted and inserted lines that only consist of
+	// whitespace, which are otherwise invisible. A diff that's written this way can't be applied with "git apply".
+	ShowWhitespace bool
+}
+
+// Diff returns the hunks that turn before into after, which are empty if both inputs have the same content.
+func (d Differ) Diff(before, after *Input) []Hunk {
+	a, b := diffLines(before), diffLines(after)
+	script := editScript(a, b)
+	context := max(d.Context, 0)
+
+	var hunks []Hunk
+
+	for idx := 0; idx < len(script); {
+		if script[idx].Op == Equal {
+			idx++
+
+			continue
+		}
+
+		start, end := max(idx-context, 0), idx
+
+		for end < len(script) {
+			if script[end].Op != Equal {
+				end++
+
+				continue
+			}
+
+			run := end
+
+			for run < len(script) && script[run].Op == Equal {
+				run++
+			}
+
+			if run == len(script) || run-end > 2*context {
+				end = min(end+context, run)
+
+				break
+			}
+
+			end = run
+		}
+
+		hunks = append(hunks, newHunk(script[start:end]))
+		idx = end
+	}
+
+	return hunks
+}
+
+// Write writes the unified diff that turns before into after to w, using the given names in the file header.
+//
+// Nothing is written if both inputs have the same content. An error is returned if w can't be written to.
+func (d Differ) Write(w io.Writer, oldName, newName string, before, after *Input) error {
+	hunks := d.Diff(before, after)
+
+	if len(hunks) == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("--- " + oldName + "\n")
+	sb.WriteString("+++ " + newName + "\n")
+
+	for _, hunk := range hunks {
+		sb.WriteString(hunk.Header() + "\n")
+
+		for _, line := range hunk.Lines {
+			text, lineBreak := splitLineBreak(line.Text)
+
+			if d.ShowWhitespace && line.Op != Equal && text != "" && strings.Trim(text, " \t") == "" {
+				text = strings.NewReplacer(" ", "·", "\t", "→").Replace(text)
+			}
+
+			sb.WriteString(opPrefix[line.Op] + text)
+
+			if lineBreak == "" {
+				sb.WriteString("\n" + noNewlineMarker)
+			} else {
+				sb.WriteString(lineBreak)
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+// An entry of an edit script, together with the 0-based index of the line in both inputs where it applies.
+type scriptEntry struct {
+	DiffLine
+
+	old, new int
+}
+
+// Returns the lines of input, each including its line break.
+//
+// Unlike [Input.LineCount], the empty line that follows a final line break isn't counted.
+func diffLines(input *Input) []string {
+	starts := input.lineStarts()
+	lines := make([]string, 0, len(starts))
+
+	for idx, start := range starts {
+		end := len(input.Content)
+
+		if idx+1 < len(starts) {
+			end = starts[idx+1]
+		}
+
+		if start < end {
+			lines = append(lines, input.Content[start:end])
+		}
+	}
+
+	return lines
+}
+
+// Returns the shortest edit script that turns a into b, with the deletions of every change before its insertions.
+func editScript(a, b []string) []scriptEntry {
+	// Lines are compared by number instead of by content.
+	ids := make(map[string]int)
+	intern := func(lines []string) []int {
+		out := make([]int, len(lines))
+
+		for idx, line := range lines {
+			id, ok := ids[line]
+
+			if !ok {
+				id = len(ids)
+				ids[line] = id
+			}
+
+			out[idx] = id
+		}
+
+		return out
+	}
+
+	ops := myers(intern(a), intern(b))
+	script := make([]scriptEntry, 0, len(ops))
+
+	for x, y, idx := 0, 0, 0; idx < len(ops); {
+		if ops[idx] == Equal {
+			script = append(script, scriptEntry{DiffLine{Equal, a[x]}, x, y})
+			x, y, idx = x+1, y+1, idx+1
+
+			continue
+		}
+
+		// Emit the deletions of the change before its insertions.
+		end := idx
+
+		for end < len(ops) && ops[end] != Equal {
+			end++
+		}
+
+		newY := y
+
+		for _, op := range ops[idx:end] {
+			if op == Delete {
+				script = append(script, scriptEntry{DiffLine{Delete, a[x]}, x, newY})
+				x++
+			}
+		}
+
+		for _, op := range ops[idx:end] {
+			if op == Insert {
+				script = append(script, scriptEntry{DiffLine{Insert, b[y]}, x, y})
+				y++
+			}
+		}
+
+		idx = end
+	}
+
+	return script
+}
+
+// Returns the operations that turn a into b, using the linear space variant of the algorithm of Myers: the middle snake
+// of a shortest edit script splits the inputs in 2 halves, which are compared recursively. Only 2 vectors of furthest
+// reaching paths are kept, so the memory that's used grows with the length of the inputs rather than with the product
+// of their length and the number of differences.
+func myers(a, b []int) []Op {
+	size := 2*((len(a)+len(b)+1)/2+1) + 1
+
+	return diffRange(make([]Op, 0, len(a)+len(b)), a, b, make([]int, size), make([]int, size))
+}
+
+// Appends the operations that turn a into b to ops, using forward and backward as the vectors of furthest reaching
+// paths, see [myers].
+func diffRange(ops []Op, a, b []int, forward, backward []int) []Op {
+	prefix, suffix := 0, 0
+
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops = appendOps(ops, Equal, prefix)
+	a, b = a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	switch {
+	case len(a) == 0:
+		ops = appendOps(ops, Insert, len(b))
+
+	case len(b) == 0:
+		ops = appendOps(ops, Delete, len(a))
+
+	default:
+		x, y, u, v := middleSnake(a, b, forward, backward)
+		ops = diffRange(ops, a[:x], b[:y], forward, backward)
+		ops = appendOps(ops, Equal, u-x)
+		ops = diffRange(ops, a[u:], b[v:], forward, backward)
+	}
+
+	return appendOps(ops, Equal, suffix)
+}
+
+// Returns the start (x, y) and the end (u, v) of the middle snake of a shortest edit script that turns a into b, which
+// both aren't empty. The paths are searched from the start and from the end of the inputs at the same time, until they
+// overlap. A path from the end is stored as offsets from the end of the inputs.
+func middleSnake(a, b []int, forward, backward []int) (int, int, int, int) {
+	n, m := len(a), len(b)
+	limit := (n + m + 1) / 2
+	offset := limit + 1
+	delta := n - m
+	odd := delta%2 != 0
+
+	for idx := range 2*offset + 1 {
+		forward[idx], backward[idx] = -1, -1
+	}
+
+	forward[offset+1], backward[offset+1] = 0, 0
+
+	for d := 0; d <= limit; d++ {
+		for k := -d; k <= d; k += 2 {
+			x := furthest(forward, offset, k, d)
+			y := x - k
+			startX, startY := x, y
+
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+
+			forward[offset+k] = x
+
+			// On diagonal k, the path from the end is on diagonal delta-k.
+			if c := delta - k; odd && c >= -(d-1) && c <= d-1 && backward[offset+c] >= 0 && x+backward[offset+c] >= n {
+				return startX, startY, x, y
+			}
+		}
+
+		for k := -d; k <= d; k += 2 {
+			x := furthest(backward, offset, k, d)
+			y := x - k
+			startX, startY := x, y
+
+			for x < n && y < m && a[n-1-x] == b[m-1-y] {
+				x, y = x+1, y+1
+			}
+
+			backward[offset+k] = x
+
+			if c := delta - k; !odd && c >= -d && c <= d && forward[offset+c] >= 0 && forward[offset+c]+x >= n {
+				return n - x, m - y, n - startX, m - startY
+			}
+		}
+	}
+
+	panic("text: no middle snake")
+}
+
+// Returns the x coordinate where the path on diagonal k starts after d differences, which continues the furthest
+// reaching path of the neighbouring diagonals in v.
+func furthest(v []int, offset, k, d int) int {
+	if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+		return v[offset+k+1]
+	}
+
+	return v[offset+k-1] + 1
+}
+
+// Appends n times op to ops.
+func appendOps(ops []Op, op Op, n int) []Op {
+	for range n {
+		ops = append(ops, op)
+	}
+
+	return ops
+}
+
+// Returns the hunk made of script, which must not be empty.
+func newHunk(script []scriptEntry) Hunk {
+	hunk := Hunk{
+		OldStart: script[0].old + 1,
+		NewStart: script[0].new + 1,
+		Lines:    make([]DiffLine, 0, len(script)),
+	}
+
+	for _, entry := range script {
+		if entry.Op != Insert {
+			hunk.OldLines++
+		}
+
+		if entry.Op != Delete {
+			hunk.NewLines++
+		}
+
+		hunk.Lines = append(hunk.Lines, entry.DiffLine)
+	}
+
+	return hunk
+}
+
+// Returns the range of a hunk in one of the inputs, as it's written in the header of a hunk.
+func hunkRange(start, lines int) string {
+	switch lines {
+	case 0:
+		return strconv.Itoa(start-1) + ",0"
+	case 1:
+		return strconv.Itoa(start)
+	default:
+		return strconv.Itoa(start) + "," + strconv.Itoa(lines)
+	}
+}
+
+// Returns line without its line break, and the line break itself.
+func splitLineBreak(line string) (text, lineBreak string) {
+	switch {
+	case strings.HasSuffix(line, "\r\n"):
+		return line[:len(line)-2], "\r\n"
+	case strings.HasSuffix(line, "\n"), strings.HasSuffix(line, "\r"):
+		return line[:len(line)-1], line[len(line)-1:]
+	default:
+		return line, ""
+	}
+}
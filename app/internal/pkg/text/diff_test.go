@@ -0,0 +1,328 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify the implementation of the "text" package.
+package text_test
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/kdeconinck/lens/internal/pkg/assert"
+	"github.com/kdeconinck/lens/internal/pkg/text"
+)
+
+// UT: Get the human-readable representation of an operation.
+func Test_OpString(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		opInput text.Op
+		want    string
+	}{
+		"When the operation is 'Equal', it's displayed as 'Equal'.": {
+			opInput: text.Equal,
+			want:    "Equal",
+		},
+		"When the operation is 'Delete', it's displayed as 'Delete'.": {
+			opInput: text.Delete,
+			want:    "Delete",
+		},
+		"When the operation is 'Insert', it's displayed as 'Insert'.": {
+			opInput: text.Insert,
+			want:    "Insert",
+		},
+		"When the operation is unknown, it's displayed as 'Unknown(X)'.": {
+			opInput: text.Op(99),
+			want:    "Unknown(99)",
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := tc.opInput.String()
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Get the header of a hunk.
+func TestHunk_Header(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		hunkInput text.Hunk
+		want      string
+	}{
+		"When both sides have multiple lines, the start and the count are shown.": {
+			hunkInput: text.Hunk{OldStart: 3, OldLines: 4, NewStart: 3, NewLines: 5},
+			want:      "@@ -3,4 +3,5 @@",
+		},
+		"When a side has a single line, its count is omitted.": {
+			hunkInput: text.Hunk{OldStart: 2, OldLines: 1, NewStart: 2, NewLines: 2},
+			want:      "@@ -2 +2,2 @@",
+		},
+		"When a side has no lines, the line before the hunk is shown.": {
+			hunkInput: text.Hunk{OldStart: 1, OldLines: 0, NewStart: 1, NewLines: 2},
+			want:      "@@ -0,0 +1,2 @@",
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := tc.hunkInput.Header()
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Verify that a unified diff is written between 2 inputs.
+func TestDiffer_Write(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		differInput text.Differ
+		oldInput    string
+		newInput    string
+		want        string
+	}{
+		"When both inputs are equal, nothing is written.": {
+			differInput: text.Differ{Context: text.DefaultContext},
+			oldInput:    "a\nb\n",
+			newInput:    "a\nb\n",
+			want:        "",
+		},
+		"When a line is replaced, it's deleted and inserted, surrounded by context.": {
+			differInput: text.Differ{Context: text.DefaultContext},
+			oldInput:    "a\nb\nc\n",
+			newInput:    "a\nB\nc\n",
+			want: "--- old\n" +
+				"+++ new\n" +
+				"@@ -1,3 +1,3 @@\n" +
+				" a\n" +
+				"-b\n" +
+				"+B\n" +
+				" c\n",
+		},
+		"When the old input is empty, every line is inserted.": {
+			differInput: text.Differ{Context: text.DefaultContext},
+			oldInput:    "",
+			newInput:    "a\nb\n",
+			want: "--- old\n" +
+				"+++ new\n" +
+				"@@ -0,0 +1,2 @@\n" +
+				"+a\n" +
+				"+b\n",
+		},
+		"When changes are close to each other, they share a hunk.": {
+			differInput: text.Differ{Context: 1},
+			oldInput:    "1\n2\n3\n4\n5\n",
+			newInput:    "1\nX\n3\nY\n5\n",
+			want: "--- old\n" +
+				"+++ new\n" +
+				"@@ -1,5 +1,5 @@\n" +
+				" 1\n" +
+				"-2\n" +
+				"+X\n" +
+				" 3\n" +
+				"-4\n" +
+				"+Y\n" +
+				" 5\n",
+		},
+		"When changes are far from each other, they're put in separate hunks.": {
+			differInput: text.Differ{Context: 1},
+			oldInput:    "1\n2\n3\n4\n5\n6\n7\n",
+			newInput:    "X\n2\n3\n4\n5\n6\nY\n",
+			want: "--- old\n" +
+				"+++ new\n" +
+				"@@ -1,2 +1,2 @@\n" +
+				"-1\n" +
+				"+X\n" +
+				" 2\n" +
+				"@@ -6,2 +6,2 @@\n" +
+				" 6\n" +
+				"-7\n" +
+				"+Y\n",
+		},
+		"When there's no context, only the changed lines are shown.": {
+			differInput: text.Differ{Context: 0},
+			oldInput:    "a\nb\nc\n",
+			newInput:    "a\nc\n",
+			want: "--- old\n" +
+				"+++ new\n" +
+				"@@ -2 +1,0 @@\n" +
+				"-b\n",
+		},
+		"When a final line break is added, the old line is marked as lacking one.": {
+			differInput: text.Differ{Context: text.DefaultContext},
+			oldInput:    "a\nb",
+			newInput:    "a\nb\n",
+			want: "--- old\n" +
+				"+++ new\n" +
+				"@@ -1,2 +1,2 @@\n" +
+				" a\n" +
+				"-b\n" +
+				"\\ No newline at end of file\n" +
+				"+b\n",
+		},
+		"When both inputs lack a final line break, the context line is marked as lacking one.": {
+			differInput: text.Differ{Context: text.DefaultContext},
+			oldInput:    "a\nb",
+			newInput:    "A\nb",
+			want: "--- old\n" +
+				"+++ new\n" +
+				"@@ -1,2 +1,2 @@\n" +
+				"-a\n" +
+				"+A\n" +
+				" b\n" +
+				"\\ No newline at end of file\n",
+		},
+		"When whitespace is shown, lines that only consist of whitespace are made visible.": {
+			differInput: text.Differ{Context: text.DefaultContext, ShowWhitespace: true},
+			oldInput:    "a\n  \t\nb\n",
+			newInput:    "a\n\nb \n",
+			want: "--- old\n" +
+				"+++ new\n" +
+				"@@ -1,3 +1,3 @@\n" +
+				" a\n" +
+				"-··→\n" +
+				"-b\n" +
+				"+\n" +
+				"+b \n",
+		},
+		"When a line break changes style, the line is replaced.": {
+			differInput: text.Differ{Context: text.DefaultContext},
+			oldInput:    "a\nb\n",
+			newInput:    "a\r\nb\n",
+			want: "--- old\n" +
+				"+++ new\n" +
+				"@@ -1,2 +1,2 @@\n" +
+				"-a\n" +
+				"+a\r\n" +
+				" b\n",
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			var sb strings.Builder
+
+			before, after := newInput(tc.oldInput), newInput(tc.newInput)
+
+			// Act.
+			err := tc.differInput.Write(&sb, "old", "new", &before, &after)
+
+			// Assert.
+			assert.Equalf(t, err, nil, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: <nil>\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, err)
+
+			assert.Equalf(t, sb.String(), tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected:\n%s\033[0m\n"+
+				"\033[31mActual:\n%s\033[0m\n\n", tcName, tc.want, sb.String())
+		})
+	}
+}
+
+// UT: Verify that the diff of 2 inputs is minimal.
+func TestDiffer_Diff(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	before, after := newInput("a\nb\nc\na\nb\nb\na\n"), newInput("c\nb\na\nb\na\nc\n")
+
+	// Act.
+	hunks := text.Differ{}.Diff(&before, &after)
+
+	// Assert.
+	var changed int
+
+	for _, hunk := range hunks {
+		for _, line := range hunk.Lines {
+			if line.Op != text.Equal {
+				changed++
+			}
+		}
+	}
+
+	assert.Equalf(t, changed, 5, "\n\n"+
+		"UT Name:  When comparing 2 inputs, the shortest edit script is used.\n"+
+		"\033[32mExpected: %d changed lines\033[0m\n"+
+		"\033[31mActual:   %d changed lines\033[0m\n\n", 5, changed)
+}
+
+// UT: Verify that comparing large inputs that differ everywhere doesn't use memory for every difference.
+func TestDiffer_Diff_Large(t *testing.T) {
+	// No parallel execution, because allocations can't be counted while other tests are running.
+
+	// Arrange.
+	const lines = 3_000
+
+	var oldContent, newContent strings.Builder
+
+	for idx := range lines {
+		fmt.Fprintf(&oldContent, "old line %d\n", idx)
+		fmt.Fprintf(&newContent, "new line %d\n", idx)
+	}
+
+	before, after := newInput(oldContent.String()), newInput(newContent.String())
+
+	var memBefore, memAfter runtime.MemStats
+
+	// Act.
+	runtime.ReadMemStats(&memBefore)
+	hunks := text.Differ{}.Diff(&before, &after)
+	runtime.ReadMemStats(&memAfter)
+
+	allocated := memAfter.TotalAlloc - memBefore.TotalAlloc
+
+	// Assert.
+	assert.Equalf(t, len(hunks) == 1 && hunks[0].OldLines == lines && hunks[0].NewLines == lines, true, "\n\n"+
+		"UT Name:  When every line of large inputs changes, a single hunk replaces all lines.\n"+
+		"\033[32mExpected: 1 hunk with %d old and %d new lines\033[0m\n"+
+		"\033[31mActual:   %d hunks\033[0m\n\n", lines, lines, len(hunks))
+
+	assert.Equalf(t, allocated < 4<<20, true, "\n\n"+
+		"UT Name:  When every line of large inputs changes, the allocated memory grows linearly.\n"+
+		"\033[32mExpected: < %d bytes\033[0m\n"+
+		"\033[31mActual:   %d bytes\033[0m\n\n", 4<<20, allocated)
+}
@@ -0,0 +1,352 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package text provides foundational types for managing and measuring text content.
+//
+// It serves as a coordinate system for UTF-8 encoded strings, defining how raw byte segments [Span]s map to
+// human-readable dimensions like line and columns.
+package text
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ErrVersionOutOfRange is reported when a version of a [Document] doesn't exist.
+var ErrVersionOutOfRange = errors.New("version out of range")
+
+// A contiguous run of text in one of the buffers of a [Document].
+type piece struct {
+	added bool // Whether the text is stored in the add buffer instead of the original content.
+	start int  // The byte offset of the text in its buffer.
+	len   int  // The length of the text in bytes.
+}
+
+// A change that was made to a [Document], expressed in the offsets of the version it was made to.
+type change struct {
+	span Span // The region that was replaced.
+	n    int  // The length of the text that replaced it.
+}
+
+// Document is an editable text, meant for content that changes frequently, such as a file that's open in an editor.
+//
+// The content is stored in a piece table, so an edit never copies the text that's already there, and the line-start
+// table is updated in place instead of being rebuilt. Every edit creates a new version: [Document.Snapshot] turns the
+// current version into an [Input] for the scanner, and [Document.TranslateSpan] moves spans between versions.
+type Document struct {
+	original string   // The content the document was created with.
+	add      []byte   // The text inserted by edits, in the order it was inserted.
+	pieces   []piece  // The pieces that make up the content, in order.
+	length   int      // The length of the content in bytes.
+	lines    []int    // The byte offset at which each line starts.
+	encoding Encoding // The encoding of the input the document was created from.
+	history  []change // The changes that were made, one per version.
+	snapshot *Input   // The snapshot of the current version, if one was taken.
+}
+
+// NewDocument initializes a [Document] with the content of input.
+func NewDocument(input *Input) *Document {
+	doc := &Document{
+		original: input.Content,
+		length:   len(input.Content),
+		lines:    slices.Clone(input.lineStarts()),
+		encoding: input.Encoding,
+	}
+
+	if doc.length > 0 {
+		doc.pieces = []piece{{start: 0, len: doc.length}}
+	}
+
+	return doc
+}
+
+// Len returns the length of the content in bytes.
+func (doc *Document) Len() int {
+	return doc.length
+}
+
+// Version returns the number of edits that have been made to the document.
+func (doc *Document) Version() int {
+	return len(doc.history)
+}
+
+// LineCount returns the number of lines in the document.
+func (doc *Document) LineCount() int {
+	return len(doc.lines)
+}
+
+// LineSpan returns the [Span] covering the 1-based line n, without its line break.
+//
+// LineSpan panics if n is outside the range [1, LineCount()].
+func (doc *Document) LineSpan(n int) Span {
+	if n < 1 || n > len(doc.lines) {
+		panic("text: line number out of range")
+	}
+
+	span := Span{Start: doc.lines[n-1], End: doc.length}
+
+	if n < len(doc.lines) {
+		span.End = doc.lines[n] - 1
+
+		if span.End > span.Start && doc.byteAt(span.End-1) == '\r' && doc.byteAt(span.End) == '\n' {
+			span.End--
+		}
+	}
+
+	return span
+}
+
+// Slice returns the content covered by span.
+//
+// Slice panics if span doesn't fit in the document.
+func (doc *Document) Slice(span Span) string {
+	if err := span.validate(doc.length); err != nil {
+		panic("text: " + err.Error())
+	}
+
+	var sb strings.Builder
+
+	sb.Grow(span.Len())
+
+	for idx, start := 0, 0; idx < len(doc.pieces) && start < span.End; idx++ {
+		p := doc.pieces[idx]
+
+		if from, to := max(span.Start-start, 0), min(span.End-start, p.len); from < to {
+			sb.WriteString(doc.text(p)[from:to])
+		}
+
+		start += p.len
+	}
+
+	return sb.String()
+}
+
+// LocationOf translates a 0-based byte offset into a 1-based line number and a 1-based column measured as described by
+// cols.
+//
+// Offsets are clamped like they are by [Input.LocationOf], and only the line containing the offset is read.
+func (doc *Document) LocationOf(offset int, cols Columns) Location {
+	if offset > doc.length {
+		offset = doc.length - 1
+	}
+
+	offset = max(offset, 0)
+
+	line := sort.Search(len(doc.lines), func(i int) bool {
+		return doc.lines[i] > offset
+	})
+
+	span := doc.LineSpan(line)
+
+	return Location{
+		Line:   line,
+		Column: columnIn(doc.Slice(span), offset-span.Start, cols),
+	}
+}
+
+// OffsetOf translates a 1-based line number and a 1-based column measured as described by cols into a 0-based byte
+// offset.
+//
+// Locations are validated like they are by [Input.OffsetOf], and only the line they refer to is read.
+func (doc *Document) OffsetOf(loc Location, cols Columns) (int, error) {
+	if loc.Line < 1 || loc.Line > len(doc.lines) {
+		return 0, fmt.Errorf("%w: %s (the document has %d lines)", ErrLineOutOfRange, loc, len(doc.lines))
+	}
+
+	span := doc.LineSpan(loc.Line)
+	offset, err := offsetIn(doc.Slice(span), loc, cols)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return span.Start + offset, nil
+}
+
+// Edit replaces the text covered by the span of edit with its new text, which creates a new version.
+//
+// An edit that doesn't fit in the document is reported as an error, and leaves the document unchanged.
+func (doc *Document) Edit(edit TextEdit) error {
+	if err := edit.Span.validate(doc.length); err != nil {
+		return err
+	}
+
+	first := doc.split(edit.Span.Start)
+	last := doc.split(edit.Span.End)
+
+	var inserted []piece
+
+	if edit.NewText != "" {
+		// Typing produces a sequence of insertions, each one right after the previous, which share a single piece.
+		if prev := first - 1; first == last && prev >= 0 && doc.pieces[prev].added &&
+			doc.pieces[prev].start+doc.pieces[prev].len == len(doc.add) {
+			doc.pieces[prev].len += len(edit.NewText)
+		} else {
+			inserted = append(inserted, piece{added: true, start: len(doc.add), len: len(edit.NewText)})
+		}
+
+		doc.add = append(doc.add, edit.NewText...)
+	}
+
+	doc.pieces = slices.Replace(doc.pieces, first, last, inserted...)
+	doc.length += len(edit.NewText) - edit.Span.Len()
+	doc.history = append(doc.history, change{span: edit.Span, n: len(edit.NewText)})
+	doc.snapshot = nil
+	doc.updateLines(edit.Span, len(edit.NewText))
+
+	return nil
+}
+
+// Snapshot returns the content of the current version as an [Input].
+//
+// The line-start table of the snapshot is copied from the document instead of being rebuilt, and the snapshot is
+// reused until the document is edited again.
+func (doc *Document) Snapshot() *Input {
+	if doc.snapshot != nil {
+		return doc.snapshot
+	}
+
+	input := &Input{
+		Content:  doc.Slice(Span{Start: 0, End: doc.length}),
+		Encoding: doc.encoding,
+	}
+
+	input.once.Do(func() {
+		input.lines = slices.Clone(doc.lines)
+	})
+
+	doc.snapshot = input
+
+	return input
+}
+
+// TranslateSpan moves a span from version from of the document to version to, as [Span.Shift] does for every edit in
+// between.
+//
+// Versions can be translated in both directions. A span that's translated back to a version before the text it covers
+// was inserted becomes empty.
+func (doc *Document) TranslateSpan(span Span, from, to int) (Span, error) {
+	for _, version := range []int{from, to} {
+		if version < 0 || version > len(doc.history) {
+			return Span{}, fmt.Errorf("%w: %d (the document has %d versions)", ErrVersionOutOfRange, version,
+				len(doc.history)+1)
+		}
+	}
+
+	for idx := from; idx < to; idx++ {
+		c := doc.history[idx]
+		span = span.Shift(c.span, c.n)
+	}
+
+	for idx := from - 1; idx >= to; idx-- {
+		c := doc.history[idx]
+		span = span.Shift(Span{Start: c.span.Start, End: c.span.Start + c.n}, c.span.Len())
+	}
+
+	return span, nil
+}
+
+// Returns the text of p.
+func (doc *Document) text(p piece) string {
+	if p.added {
+		return string(doc.add[p.start : p.start+p.len])
+	}
+
+	return doc.original[p.start : p.start+p.len]
+}
+
+// Returns the byte at offset, which must be in the range [0, Len()).
+func (doc *Document) byteAt(offset int) byte {
+	for _, p := range doc.pieces {
+		if offset < p.len {
+			if p.added {
+				return doc.add[p.start+offset]
+			}
+
+			return doc.original[p.start+offset]
+		}
+
+		offset -= p.len
+	}
+
+	panic("text: offset out of range")
+}
+
+// Makes sure that a piece starts at offset, and returns its index.
+//
+// When offset is Len(), the returned index is the number of pieces.
+func (doc *Document) split(offset int) int {
+	for idx, start := 0, 0; idx < len(doc.pieces); idx++ {
+		p := doc.pieces[idx]
+
+		if offset == start {
+			return idx
+		}
+
+		if offset < start+p.len {
+			head := piece{added: p.added, start: p.start, len: offset - start}
+			tail := piece{added: p.added, start: p.start + head.len, len: p.len - head.len}
+			doc.pieces = slices.Replace(doc.pieces, idx, idx+1, head, tail)
+
+			return idx + 1
+		}
+
+		start += p.len
+	}
+
+	return len(doc.pieces)
+}
+
+// Updates the line-start table after the text covered by edit was replaced by n bytes.
+//
+// Whether an offset starts a line only depends on the byte before it and the byte at it, so only the starts in the
+// range [edit.Start, edit.End] are affected; the ones after it are shifted.
+func (doc *Document) updateLines(edit Span, n int) {
+	first := sort.SearchInts(doc.lines, max(edit.Start, 1))
+	last := sort.SearchInts(doc.lines, edit.End+1)
+	delta := n - edit.Len()
+
+	var starts []int
+
+	for offset := max(edit.Start, 1); offset <= min(edit.Start+n, doc.length); offset++ {
+		switch doc.byteAt(offset - 1) {
+		case '\n':
+			starts = append(starts, offset)
+		case '\r':
+			if offset == doc.length || doc.byteAt(offset) != '\n' {
+				starts = append(starts, offset)
+			}
+		}
+	}
+
+	for idx := last; idx < len(doc.lines); idx++ {
+		doc.lines[idx] += delta
+	}
+
+	doc.lines = slices.Replace(doc.lines, first, last, starts...)
+}
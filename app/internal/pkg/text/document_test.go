@@ -0,0 +1,283 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify the implementation of the "text" package.
+package text_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kdeconinck/lens/internal/pkg/assert"
+	"github.com/kdeconinck/lens/internal/pkg/text"
+)
+
+// UT: Verify that edits are applied to a document, and that its lines are kept up to date.
+func TestDocument_Edit(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		contentInput string
+		editsInput   []text.TextEdit
+		want         string
+	}{
+		"When there are no edits, the content is unchanged.": {
+			contentInput: "a\nb",
+			editsInput:   nil,
+			want:         "a\nb",
+		},
+		"When text is typed character by character, it's inserted.": {
+			contentInput: "ad",
+			editsInput:   []text.TextEdit{newEdit(1, 1, "b"), newEdit(2, 2, "\n"), newEdit(3, 3, "c")},
+			want:         "ab\ncd",
+		},
+		"When an edit spans multiple pieces, they're all replaced.": {
+			contentInput: "one\ntwo\nthree",
+			editsInput:   []text.TextEdit{newEdit(4, 4, "2\n"), newEdit(0, 0, "0\n"), newEdit(1, 8, "")},
+			want:         "0two\nthree",
+		},
+		"When a line break is removed, the lines are joined.": {
+			contentInput: "a\nb\nc",
+			editsInput:   []text.TextEdit{newEdit(1, 2, "")},
+			want:         "ab\nc",
+		},
+		"When a CR is inserted before a LF, they become a single line break.": {
+			contentInput: "a\nb",
+			editsInput:   []text.TextEdit{newEdit(1, 1, "\r")},
+			want:         "a\r\nb",
+		},
+		"When a LF is inserted after a CR, they become a single line break.": {
+			contentInput: "a\rb",
+			editsInput:   []text.TextEdit{newEdit(2, 2, "\n")},
+			want:         "a\r\nb",
+		},
+		"When text is inserted between a CR and a LF, they become 2 line breaks.": {
+			contentInput: "a\r\nb",
+			editsInput:   []text.TextEdit{newEdit(2, 2, "x")},
+			want:         "a\rx\nb",
+		},
+		"When the whole content is replaced, the new content is used.": {
+			contentInput: "a\nb\n",
+			editsInput:   []text.TextEdit{newEdit(0, 4, "\r\r\n\n")},
+			want:         "\r\r\n\n",
+		},
+		"When the document is empty, text can be added.": {
+			contentInput: "",
+			editsInput:   []text.TextEdit{newEdit(0, 0, "a\n"), newEdit(2, 2, "b")},
+			want:         "a\nb",
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			input := newInput(tc.contentInput)
+			doc := text.NewDocument(&input)
+
+			// Act.
+			for _, edit := range tc.editsInput {
+				if err := doc.Edit(edit); err != nil {
+					t.Fatalf("Edit(%s) = %v", edit, err)
+				}
+			}
+
+			// Assert.
+			got := doc.Snapshot().Content
+
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %q\033[0m\n"+
+				"\033[31mActual:   %q\033[0m\n\n", tcName, tc.want, got)
+
+			want := newInput(tc.want)
+
+			assert.Equalf(t, doc.LineCount(), want.LineCount(), "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d lines\033[0m\n"+
+				"\033[31mActual:   %d lines\033[0m\n\n", tcName, want.LineCount(), doc.LineCount())
+
+			for n := 1; n <= min(doc.LineCount(), want.LineCount()); n++ {
+				assert.Equalf(t, doc.LineSpan(n), want.LineSpan(n), "\n\n"+
+					"UT Name:  %s\n"+
+					"\033[32mExpected: line %d at %s\033[0m\n"+
+					"\033[31mActual:   line %d at %s\033[0m\n\n", tcName, n, want.LineSpan(n), n, doc.LineSpan(n))
+			}
+		})
+	}
+}
+
+// UT: Verify that edits outside of the document are rejected.
+func TestDocument_Edit_Invalid(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	input := newInput("abc")
+	doc := text.NewDocument(&input)
+
+	// Act.
+	err := doc.Edit(newEdit(2, 5, ""))
+
+	// Assert.
+	assert.Equalf(t, errors.Is(err, text.ErrSpanOutOfRange), true, "\n\n"+
+		"UT Name:  When an edit doesn't fit in the document, an error is returned.\n"+
+		"\033[32mExpected: %v\033[0m\n"+
+		"\033[31mActual:   %v\033[0m\n\n", text.ErrSpanOutOfRange, err)
+
+	assert.Equalf(t, doc.Version(), 0, "\n\n"+
+		"UT Name:  When an edit doesn't fit in the document, no version is created.\n"+
+		"\033[32mExpected: %d\033[0m\n"+
+		"\033[31mActual:   %d\033[0m\n\n", 0, doc.Version())
+}
+
+// UT: Verify that locations are resolved against the current version of a document.
+func TestDocument_LocationOf(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	input := newInput("name = \"x\"\n")
+	doc := text.NewDocument(&input)
+
+	_ = doc.Edit(newEdit(0, 0, "# 日本\r\n"))
+
+	for offset, want := range map[int]text.Location{
+		0:  newLocation(1, 1),
+		5:  newLocation(1, 4),
+		8:  newLocation(1, 5),
+		10: newLocation(2, 1),
+		17: newLocation(2, 8),
+		20: newLocation(2, 11),
+		21: newLocation(3, 1),
+	} {
+		// Act.
+		got := doc.LocationOf(offset, text.Columns{})
+		back, err := doc.OffsetOf(got, text.Columns{})
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When resolving an offset in a document, the location in the current version is returned.\n"+
+			"\033[32mExpected: %d => %s\033[0m\n"+
+			"\033[31mActual:   %d => %s\033[0m\n\n", offset, want, offset, got)
+
+		assert.Equalf(t, back, offset, "\n\n"+
+			"UT Name:  When resolving a location in a document, the offset in the current version is returned.\n"+
+			"\033[32mExpected: %s => %d (<nil>)\033[0m\n"+
+			"\033[31mActual:   %s => %d (%v)\033[0m\n\n", got, offset, got, back, err)
+	}
+}
+
+// UT: Verify that a snapshot of a document is reused until the document is edited.
+func TestDocument_Snapshot(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	input := newInput("a\nb")
+	doc := text.NewDocument(&input)
+
+	// Act.
+	first, second := doc.Snapshot(), doc.Snapshot()
+	_ = doc.Edit(newEdit(3, 3, "c"))
+	third := doc.Snapshot()
+
+	// Assert.
+	assert.Equalf(t, first == second, true, "\n\n"+
+		"UT Name:  When the document isn't edited, the same snapshot is returned.\n"+
+		"\033[32mExpected: %t\033[0m\n"+
+		"\033[31mActual:   %t\033[0m\n\n", true, first == second)
+
+	assert.Equalf(t, third.Content, "a\nbc", "\n\n"+
+		"UT Name:  When the document is edited, a new snapshot is returned.\n"+
+		"\033[32mExpected: %q\033[0m\n"+
+		"\033[31mActual:   %q\033[0m\n\n", "a\nbc", third.Content)
+
+	assert.Equalf(t, first.Content, "a\nb", "\n\n"+
+		"UT Name:  When the document is edited, older snapshots remain unchanged.\n"+
+		"\033[32mExpected: %q\033[0m\n"+
+		"\033[31mActual:   %q\033[0m\n\n", "a\nb", first.Content)
+}
+
+// UT: Verify that spans are translated between versions of a document.
+func TestDocument_TranslateSpan(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	// "version = 1.0" becomes "# version = 2.0" (version 1), and then "# version: 2.0" (version 2).
+	input := newInput("version = 1.0")
+	doc := text.NewDocument(&input)
+
+	_ = doc.Edit(newEdit(0, 0, "# "))
+	_ = doc.Edit(newEdit(12, 13, "2"))
+	_ = doc.Edit(newEdit(9, 11, ":"))
+
+	for tcName, tc := range map[string]struct {
+		spanInput text.Span
+		fromInput int
+		toInput   int
+		want      text.Span
+	}{
+		"When translating to the same version, the span is unchanged.": {
+			spanInput: newSpan(0, 7), fromInput: 1, toInput: 1, want: newSpan(0, 7),
+		},
+		"When translating forward, the span moves through the edits.": {
+			spanInput: newSpan(10, 13), fromInput: 0, toInput: 3, want: newSpan(11, 14),
+		},
+		"When translating backward, the span moves back through the edits.": {
+			spanInput: newSpan(2, 9), fromInput: 3, toInput: 0, want: newSpan(0, 7),
+		},
+		"When translating backward past the insertion of the text, the span becomes empty.": {
+			spanInput: newSpan(0, 2), fromInput: 3, toInput: 0, want: newSpan(0, 0),
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got, err := doc.TranslateSpan(tc.spanInput, tc.fromInput, tc.toInput)
+
+			// Assert.
+			assert.Equalf(t, err, nil, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: <nil>\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, err)
+
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+
+	t.Run("When a version doesn't exist, an error is returned.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Act.
+		_, err := doc.TranslateSpan(newSpan(0, 0), 0, 4)
+
+		// Assert.
+		assert.Equalf(t, errors.Is(err, text.ErrVersionOutOfRange), true, "\n\n"+
+			"UT Name:  When a version doesn't exist, an error is returned.\n"+
+			"\033[32mExpected: %v\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", text.ErrVersionOutOfRange, err)
+	})
+}
@@ -0,0 +1,201 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package text provides foundational types for managing and measuring text content.
+//
+// It serves as a coordinate system for UTF-8 encoded strings, defining how raw byte segments [Span]s map to
+// human-readable dimensions like line and columns.
+package text
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ErrConflictingEdits is reported when [TextEdit]s that overlap are applied together.
+var ErrConflictingEdits = errors.New("conflicting edits")
+
+// TextEdit replaces a region of an [Input] with new text.
+//
+// An edit with an empty span inserts text, and an edit with empty new text deletes the region.
+type TextEdit struct {
+	// Span is the region of the original input that's replaced.
+	Span Span
+
+	// NewText is the text that replaces the region.
+	NewText string
+}
+
+// String returns the string representation of the edit.
+func (edit TextEdit) String() string {
+	return fmt.Sprintf("%s => %q", edit.Span, edit.NewText)
+}
+
+// Conflict is a pair of [TextEdit]s that can't be applied together.
+type Conflict struct {
+	First  TextEdit
+	Second TextEdit
+}
+
+// ConflictError is returned by [Apply] when some of the edits overlap.
+type ConflictError struct {
+	// Conflicts holds every pair of overlapping edits, in order of position.
+	Conflicts []Conflict
+}
+
+// Error returns the description of the error.
+func (err *ConflictError) Error() string {
+	descriptions := make([]string, 0, len(err.Conflicts))
+
+	for _, conflict := range err.Conflicts {
+		descriptions = append(descriptions, fmt.Sprintf("%s overlaps %s", conflict.First, conflict.Second))
+	}
+
+	return fmt.Sprintf("%s: %s", ErrConflictingEdits, strings.Join(descriptions, ", "))
+}
+
+// Unwrap returns [ErrConflictingEdits].
+func (err *ConflictError) Unwrap() error {
+	return ErrConflictingEdits
+}
+
+// Mapping translates positions in an [Input] into positions in the result of applying [TextEdit]s to it.
+type Mapping struct {
+	edits  []TextEdit // The applied edits, sorted by position.
+	deltas []int      // deltas[i] is the change in length caused by the first i edits.
+}
+
+// Offset returns the position of the original byte offset in the new input.
+//
+// An offset inside a replaced region moves to the end of the new text, and so does an offset at which text was inserted.
+// The offset at which a replaced region starts stays in front of the new text.
+func (m *Mapping) Offset(offset int) int {
+	// The edits that end at or before the offset move it; text inserted at the offset is placed in front of it.
+	idx := sort.Search(len(m.edits), func(i int) bool {
+		return m.edits[i].Span.End > offset
+	})
+
+	if idx < len(m.edits) && m.edits[idx].Span.Start < offset {
+		return m.edits[idx].Span.Start + m.deltas[idx] + len(m.edits[idx].NewText)
+	}
+
+	return offset + m.deltas[idx]
+}
+
+// Span returns the position of the original span in the new input.
+//
+// The span moves through the edits as described by [Span.Shift].
+func (m *Mapping) Span(span Span) Span {
+	result := Span{
+		Start: m.Offset(span.Start),
+		End:   m.end(span.End),
+	}
+
+	if result.End < result.Start {
+		result.End = result.Start
+	}
+
+	return result
+}
+
+// Returns the position of the original offset in the new input, when that offset is the end of a span.
+//
+// Unlike [Mapping.Offset], an offset inside a replaced region moves to the start of the new text, and text inserted at
+// the offset is placed after it.
+func (m *Mapping) end(offset int) int {
+	idx := sort.Search(len(m.edits), func(i int) bool {
+		return m.edits[i].Span.Start >= offset
+	})
+
+	if idx > 0 && m.edits[idx-1].Span.End > offset {
+		return m.edits[idx-1].Span.Start + m.deltas[idx-1]
+	}
+
+	return offset + m.deltas[idx]
+}
+
+// Apply applies the edits to input and returns the resulting [Input], together with a [Mapping] that translates
+// positions in input into positions in the result.
+//
+// The edits may be given in any order; they're sorted by position first. Insertions at the same position are applied in
+// the order in which they're given. If any of the edits overlap, nothing is applied and a [*ConflictError] that lists
+// every overlapping pair is returned. An edit whose span doesn't fit in input results in the error of [Span.Validate].
+func Apply(input *Input, edits []TextEdit) (*Input, *Mapping, error) {
+	for _, edit := range edits {
+		if err := edit.Span.Validate(input); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	sorted := slices.Clone(edits)
+
+	slices.SortStableFunc(sorted, func(a, b TextEdit) int {
+		if a.Span.Start != b.Span.Start {
+			return a.Span.Start - b.Span.Start
+		}
+
+		return a.Span.End - b.Span.End
+	})
+
+	var conflicts []Conflict
+
+	for idx, last := 1, 0; idx < len(sorted); idx++ {
+		// Compare with the edit that reaches furthest, which isn't necessarily the previous one.
+		if sorted[idx].Span.Start < sorted[last].Span.End {
+			conflicts = append(conflicts, Conflict{First: sorted[last], Second: sorted[idx]})
+		}
+
+		if sorted[idx].Span.End > sorted[last].Span.End {
+			last = idx
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return nil, nil, &ConflictError{Conflicts: conflicts}
+	}
+
+	m := &Mapping{
+		edits:  sorted,
+		deltas: make([]int, len(sorted)+1),
+	}
+
+	var sb strings.Builder
+
+	prevEnd := 0
+
+	for idx, edit := range sorted {
+		sb.WriteString(input.Content[prevEnd:edit.Span.Start])
+		sb.WriteString(edit.NewText)
+		prevEnd = edit.Span.End
+		m.deltas[idx+1] = m.deltas[idx] + len(edit.NewText) - edit.Span.Len()
+	}
+
+	sb.WriteString(input.Content[prevEnd:])
+
+	return &Input{Content: sb.String(), Encoding: input.Encoding}, m, nil
+}
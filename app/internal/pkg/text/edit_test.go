@@ -0,0 +1,237 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify the implementation of the "text" package.
+package text_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kdeconinck/lens/internal/pkg/assert"
+	"github.com/kdeconinck/lens/internal/pkg/text"
+)
+
+// UT: Get the human-readable representation of an edit.
+func Test_TextEditString(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	edit := newEdit(5, 7, "abc")
+
+	// Act.
+	got, want := edit.String(), "5..7 => \"abc\""
+
+	// Assert.
+	assert.Equalf(t, got, want, "\n\n"+
+		"UT Name:  When formatting a 'TextEdit' it's displayed as 'Start..End => \"NewText\"'.\n"+
+		"\033[32mExpected: %s\033[0m\n"+
+		"\033[31mActual:   %s\033[0m\n\n", want, got)
+}
+
+// UT: Verify that a batch of edits is applied to an input.
+func Test_Apply(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		editsInput []text.TextEdit
+		want       string
+	}{
+		"When there are no edits, the content is unchanged.": {
+			editsInput: nil,
+			want:       "version = 1.0",
+		},
+		"When there's a single replacement, it's applied.": {
+			editsInput: []text.TextEdit{newEdit(10, 13, "2.0")},
+			want:       "version = 2.0",
+		},
+		"When the edits are out of order, they're sorted before being applied.": {
+			editsInput: []text.TextEdit{newEdit(10, 13, "2"), newEdit(7, 10, ": "), newEdit(0, 0, "# ")},
+			want:       "# version: 2",
+		},
+		"When multiple insertions target the same position, they're applied in the given order.": {
+			editsInput: []text.TextEdit{newEdit(7, 7, "A"), newEdit(7, 7, "B")},
+			want:       "versionAB = 1.0",
+		},
+		"When an insertion touches a replacement, both are applied.": {
+			editsInput: []text.TextEdit{newEdit(0, 7, "v"), newEdit(7, 7, "!")},
+			want:       "v! = 1.0",
+		},
+		"When text is deleted, it's removed.": {
+			editsInput: []text.TextEdit{newEdit(7, 13, "")},
+			want:       "version",
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			input := newInput("version = 1.0")
+
+			// Act.
+			got, _, err := text.Apply(&input, tc.editsInput)
+
+			// Assert.
+			assert.Equalf(t, err, nil, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: <nil>\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, err)
+
+			assert.Equalf(t, got.Content, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.want, got.Content)
+		})
+	}
+}
+
+// UT: Verify that overlapping edits are rejected.
+func Test_Apply_Conflicts(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		editsInput []text.TextEdit
+		want       []text.Conflict
+	}{
+		"When 2 replacements overlap, the conflict is reported.": {
+			editsInput: []text.TextEdit{newEdit(0, 5, "A"), newEdit(3, 8, "B")},
+			want:       []text.Conflict{{First: newEdit(0, 5, "A"), Second: newEdit(3, 8, "B")}},
+		},
+		"When an insertion is inside a replacement, the conflict is reported.": {
+			editsInput: []text.TextEdit{newEdit(4, 4, "B"), newEdit(0, 5, "A")},
+			want:       []text.Conflict{{First: newEdit(0, 5, "A"), Second: newEdit(4, 4, "B")}},
+		},
+		"When a replacement covers multiple edits, every conflict is reported.": {
+			editsInput: []text.TextEdit{newEdit(0, 10, "A"), newEdit(2, 3, "B"), newEdit(5, 6, "C")},
+			want: []text.Conflict{
+				{First: newEdit(0, 10, "A"), Second: newEdit(2, 3, "B")},
+				{First: newEdit(0, 10, "A"), Second: newEdit(5, 6, "C")},
+			},
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			input := newInput("version = 1.0")
+
+			// Act.
+			_, _, err := text.Apply(&input, tc.editsInput)
+
+			var got *text.ConflictError
+
+			// Assert.
+			assert.Equalf(t, errors.As(err, &got) && errors.Is(err, text.ErrConflictingEdits), true, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, text.ErrConflictingEdits, err)
+
+			assert.Equalf(t, len(got.Conflicts), len(tc.want), "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, tc.want, got.Conflicts)
+
+			for idx, want := range tc.want {
+				assert.Equalf(t, got.Conflicts[idx], want, "\n\n"+
+					"UT Name:  %s\n"+
+					"\033[32mExpected: #%d - %v\033[0m\n"+
+					"\033[31mActual:   #%d - %v\033[0m\n\n", tcName, idx, want, idx, got.Conflicts[idx])
+			}
+		})
+	}
+}
+
+// UT: Verify that edits outside of the input are rejected.
+func Test_Apply_Invalid(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	input := newInput("abc")
+
+	// Act.
+	_, _, err := text.Apply(&input, []text.TextEdit{newEdit(2, 5, "")})
+
+	// Assert.
+	assert.Equalf(t, errors.Is(err, text.ErrSpanOutOfRange), true, "\n\n"+
+		"UT Name:  When an edit doesn't fit in the input, an error is returned.\n"+
+		"\033[32mExpected: %v\033[0m\n"+
+		"\033[31mActual:   %v\033[0m\n\n", text.ErrSpanOutOfRange, err)
+}
+
+// UT: Verify that positions in the original input are mapped onto the new input.
+func TestMapping(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	// "version = 1.0" becomes "# version: 2.0".
+	input := newInput("version = 1.0")
+	edits := []text.TextEdit{newEdit(0, 0, "# "), newEdit(7, 9, ":"), newEdit(10, 11, "2")}
+
+	_, mapping, _ := text.Apply(&input, edits)
+
+	t.Run("When mapping offsets, they move through the edits.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		for offset, want := range map[int]int{0: 2, 3: 5, 7: 9, 8: 10, 9: 10, 10: 11, 11: 12, 13: 14} {
+			// Act.
+			got := mapping.Offset(offset)
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When mapping offsets, they move through the edits.\n"+
+				"\033[32mExpected: %d => %d\033[0m\n"+
+				"\033[31mActual:   %d => %d\033[0m\n\n", offset, want, offset, got)
+		}
+	})
+
+	t.Run("When mapping spans, they move through the edits like 'Span.Shift'.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		for span, want := range map[text.Span]text.Span{
+			newSpan(0, 7):   newSpan(2, 9),
+			newSpan(10, 13): newSpan(11, 14),
+			newSpan(11, 13): newSpan(12, 14),
+			newSpan(8, 9):   newSpan(10, 10),
+			newSpan(0, 13):  newSpan(2, 14),
+		} {
+			// Act.
+			got := mapping.Span(span)
+
+			// Assert.
+			assert.Equalf(t, got, want, "\n\n"+
+				"UT Name:  When mapping spans, they move through the edits like 'Span.Shift'.\n"+
+				"\033[32mExpected: %s => %s\033[0m\n"+
+				"\033[31mActual:   %s => %s\033[0m\n\n", span, want, span, got)
+		}
+	})
+}
+
+// Returns a new edit that replaces the region between start and end with newText.
+func newEdit(start, end int, newText string) text.TextEdit {
+	return text.TextEdit{
+		Span:    newSpan(start, end),
+		NewText: newText,
+	}
+}
@@ -0,0 +1,296 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package text provides foundational types for managing and measuring text content.
+//
+// It serves as a coordinate system for UTF-8 encoded strings, defining how raw byte segments [Span]s map to
+// human-readable dimensions like line and columns.
+package text
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// ErrInvalidEncoding is reported when bytes can't be transcoded without losing information.
+var ErrInvalidEncoding = errors.New("invalid encoding")
+
+// The byte order marks that identify an encoding.
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// The number of bytes that are inspected to detect UTF-16 without a byte order mark.
+const sniffLen = 512
+
+// The order in which the bytes of a UTF-16 code unit are stored.
+type byteOrder interface {
+	binary.ByteOrder
+	binary.AppendByteOrder
+}
+
+// Encoding represents the way in which the text of a file is stored as bytes.
+type Encoding int
+
+// The different encodings of a file.
+const (
+	UTF8 Encoding = iota
+	UTF8BOM
+	UTF16LE
+	UTF16LEBOM
+	UTF16BE
+	UTF16BEBOM
+)
+
+// Maps an [Encoding] to its human-readable name.
+var encodingMap = map[Encoding]string{
+	UTF8:       "UTF-8",
+	UTF8BOM:    "UTF-8 with BOM",
+	UTF16LE:    "UTF-16LE",
+	UTF16LEBOM: "UTF-16LE with BOM",
+	UTF16BE:    "UTF-16BE",
+	UTF16BEBOM: "UTF-16BE with BOM",
+}
+
+// String returns the string representation of the encoding.
+func (enc Encoding) String() string {
+	value, ok := encodingMap[enc]
+
+	if ok {
+		return value
+	}
+
+	return "Unknown(" + strconv.Itoa(int(enc)) + ")"
+}
+
+// Returns the byte order mark that's written in front of the text.
+func (enc Encoding) bom() []byte {
+	switch enc {
+	case UTF8BOM:
+		return bomUTF8
+
+	case UTF16LEBOM:
+		return bomUTF16LE
+
+	case UTF16BEBOM:
+		return bomUTF16BE
+	}
+
+	return nil
+}
+
+// Returns the byte order of the UTF-16 code units, or nil if the encoding isn't UTF-16.
+func (enc Encoding) byteOrder() byteOrder {
+	switch enc {
+	case UTF16LE, UTF16LEBOM:
+		return binary.LittleEndian
+
+	case UTF16BE, UTF16BEBOM:
+		return binary.BigEndian
+	}
+
+	return nil
+}
+
+// Decode creates an [Input] from the raw bytes of a file.
+//
+// The encoding is detected from the byte order mark. Without one, the data is treated as UTF-16 if the first bytes
+// look like mostly ASCII text with a zero byte in most code units, and as UTF-8 otherwise. The byte order mark is never
+// part of [Input.Content], and the detected encoding is stored in [Input.Encoding] so that [Input.Encode] can reproduce
+// the original bytes.
+//
+// UTF-8 data is taken as is, even if it isn't valid. UTF-16 data that has an odd length or contains an unpaired
+// surrogate can't be represented in UTF-8 and results in an error wrapping [ErrInvalidEncoding].
+func Decode(data []byte) (*Input, error) {
+	enc := detectEncoding(data)
+	data = data[len(enc.bom()):]
+
+	order := enc.byteOrder()
+
+	if order == nil {
+		return &Input{Content: string(data), Encoding: enc}, nil
+	}
+
+	if len(data)%2 != 0 {
+		return nil, fmt.Errorf("%w: %s data has an odd length", ErrInvalidEncoding, enc)
+	}
+
+	var sb strings.Builder
+
+	sb.Grow(len(data) / 2)
+
+	for idx := 0; idx < len(data); idx += 2 {
+		unit := order.Uint16(data[idx:])
+		r := rune(unit)
+
+		if utf16.IsSurrogate(r) {
+			if idx+4 <= len(data) {
+				r = utf16.DecodeRune(r, rune(order.Uint16(data[idx+2:])))
+			} else {
+				r = utf8.RuneError
+			}
+
+			if r == utf8.RuneError {
+				return nil, fmt.Errorf("%w: unpaired surrogate at byte %d", ErrInvalidEncoding, idx+len(enc.bom()))
+			}
+
+			idx += 2
+		}
+
+		sb.WriteRune(r)
+	}
+
+	return &Input{Content: sb.String(), Encoding: enc}, nil
+}
+
+// Encode returns the content of the input in its [Input.Encoding], including the byte order mark.
+//
+// Content that isn't valid UTF-8 can't be transcoded to UTF-16 and results in an error wrapping [ErrInvalidEncoding].
+func (input *Input) Encode() ([]byte, error) {
+	bom := input.Encoding.bom()
+	order := input.Encoding.byteOrder()
+
+	if order == nil {
+		return append(bytes.Clone(bom), input.Content...), nil
+	}
+
+	data := make([]byte, 0, len(bom)+2*len(input.Content))
+	data = append(data, bom...)
+
+	for offset, r := range input.Content {
+		if r == utf8.RuneError {
+			if _, size := utf8.DecodeRuneInString(input.Content[offset:]); size == 1 {
+				return nil, fmt.Errorf("%w: invalid UTF-8 at byte %d", ErrInvalidEncoding, offset)
+			}
+		}
+
+		for _, unit := range utf16.AppendRune(nil, r) {
+			data = order.AppendUint16(data, unit)
+		}
+	}
+
+	return data, nil
+}
+
+// SourceOffset translates a byte offset in [Input.Content] into the byte offset of the same position in the data that
+// the input was decoded from.
+//
+// For UTF-16 encoded input, this walks the content up to the offset, making it an O(n) operation.
+func (input *Input) SourceOffset(offset int) int {
+	bom := len(input.Encoding.bom())
+
+	if input.Encoding.byteOrder() == nil {
+		return bom + offset
+	}
+
+	units := 0
+
+	for _, r := range input.Content[:offset] {
+		units += len(utf16.AppendRune(nil, r))
+	}
+
+	return bom + 2*units
+}
+
+// Validate returns the spans of the byte sequences in the content that aren't valid UTF-8, or nil if there are none.
+//
+// A run of adjacent invalid bytes is reported as a single span. The content itself is left untouched, so it can still
+// be written back byte for byte.
+func (input *Input) Validate() []Span {
+	if utf8.ValidString(input.Content) {
+		return nil
+	}
+
+	var spans []Span
+
+	for offset := 0; offset < len(input.Content); {
+		r, size := utf8.DecodeRuneInString(input.Content[offset:])
+
+		if r == utf8.RuneError && size == 1 {
+			if last := len(spans) - 1; last >= 0 && spans[last].End == offset {
+				spans[last].End++
+			} else {
+				spans = append(spans, Span{Start: offset, End: offset + 1})
+			}
+		}
+
+		offset += size
+	}
+
+	return spans
+}
+
+// Returns the encoding of data, based on its byte order mark or, if there's none, on its first bytes.
+func detectEncoding(data []byte) Encoding {
+	switch {
+	case bytes.HasPrefix(data, bomUTF8):
+		return UTF8BOM
+
+	case bytes.HasPrefix(data, bomUTF16LE):
+		return UTF16LEBOM
+
+	case bytes.HasPrefix(data, bomUTF16BE):
+		return UTF16BEBOM
+	}
+
+	if len(data) < 2 || len(data)%2 != 0 {
+		return UTF8
+	}
+
+	sample := data[:min(len(data), sniffLen)]
+	even, odd := 0, 0
+
+	for idx := 0; idx+1 < len(sample); idx += 2 {
+		if sample[idx] == 0 {
+			even++
+		}
+
+		if sample[idx+1] == 0 {
+			odd++
+		}
+	}
+
+	units := len(sample) / 2
+
+	// Text is mostly ASCII, so at least half of its code units have a zero high byte, while the low byte of a code unit
+	// is rarely zero. Requiring every code unit to look like ASCII would miss text with a single non-Latin character.
+	switch {
+	case 2*odd >= units && even < odd:
+		return UTF16LE
+
+	case 2*even >= units && odd < even:
+		return UTF16BE
+	}
+
+	return UTF8
+}
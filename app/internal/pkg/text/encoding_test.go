@@ -0,0 +1,340 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify the implementation of the "text" package.
+package text_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kdeconinck/lens/internal/pkg/assert"
+	"github.com/kdeconinck/lens/internal/pkg/text"
+)
+
+// UT: Get the human-readable representation of an encoding.
+func Test_EncodingString(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		encInput text.Encoding
+		want     string
+	}{
+		"When the encoding is 'UTF8' it's displayed as 'UTF-8'.": {
+			encInput: text.UTF8,
+			want:     "UTF-8",
+		},
+		"When the encoding is 'UTF8BOM' it's displayed as 'UTF-8 with BOM'.": {
+			encInput: text.UTF8BOM,
+			want:     "UTF-8 with BOM",
+		},
+		"When the encoding is 'UTF16LE' it's displayed as 'UTF-16LE'.": {
+			encInput: text.UTF16LE,
+			want:     "UTF-16LE",
+		},
+		"When the encoding is 'UTF16LEBOM' it's displayed as 'UTF-16LE with BOM'.": {
+			encInput: text.UTF16LEBOM,
+			want:     "UTF-16LE with BOM",
+		},
+		"When the encoding is 'UTF16BE' it's displayed as 'UTF-16BE'.": {
+			encInput: text.UTF16BE,
+			want:     "UTF-16BE",
+		},
+		"When the encoding is 'UTF16BEBOM' it's displayed as 'UTF-16BE with BOM'.": {
+			encInput: text.UTF16BEBOM,
+			want:     "UTF-16BE with BOM",
+		},
+		"When the encoding is NOT known it's displayed as 'Unknown(xxx)'.": {
+			encInput: text.Encoding(100),
+			want:     "Unknown(100)",
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := tc.encInput.String()
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Verify that raw bytes are decoded and encoded again without any loss.
+func Test_Decode(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		dataInput    []byte
+		wantContent  string
+		wantEncoding text.Encoding
+	}{
+		"When the data is plain UTF-8, it's taken as is.": {
+			dataInput:    []byte("class A🚀"),
+			wantContent:  "class A🚀",
+			wantEncoding: text.UTF8,
+		},
+		"When the data is UTF-8 with a BOM, the BOM is stripped.": {
+			dataInput:    []byte("\xEF\xBB\xBFclass"),
+			wantContent:  "class",
+			wantEncoding: text.UTF8BOM,
+		},
+		"When the data is invalid UTF-8, it's taken as is.": {
+			dataInput:    []byte("A\xFFB"),
+			wantContent:  "A\xFFB",
+			wantEncoding: text.UTF8,
+		},
+		"When the data is UTF-16LE with a BOM, it's transcoded to UTF-8.": {
+			dataInput:    []byte{0xFF, 0xFE, 'A', 0x00, 0x3D, 0xD8, 0x80, 0xDE, '\n', 0x00},
+			wantContent:  "A🚀\n",
+			wantEncoding: text.UTF16LEBOM,
+		},
+		"When the data is UTF-16BE with a BOM, it's transcoded to UTF-8.": {
+			dataInput:    []byte{0xFE, 0xFF, 0x00, 'A', 0x65, 0xE5},
+			wantContent:  "A日",
+			wantEncoding: text.UTF16BEBOM,
+		},
+		"When the data is UTF-16LE without a BOM, it's detected and transcoded to UTF-8.": {
+			dataInput:    []byte{'c', 0x00, 'l', 0x00, 'a', 0x00, 's', 0x00, 's', 0x00},
+			wantContent:  "class",
+			wantEncoding: text.UTF16LE,
+		},
+		"When the data is UTF-16BE without a BOM, it's detected and transcoded to UTF-8.": {
+			dataInput:    []byte{0x00, 'c', 0x00, 'l', 0x00, 'a', 0x00, 's', 0x00, 's'},
+			wantContent:  "class",
+			wantEncoding: text.UTF16BE,
+		},
+		"When the data is UTF-16LE without a BOM and has non-Latin characters, it's still detected.": {
+			dataInput:    []byte{'a', 0x00, ' ', 0x00, '#', 0x00, ' ', 0x00, 0xE5, 0x65, 0x2C, 0x67, '\n', 0x00},
+			wantContent:  "a # 日本\n",
+			wantEncoding: text.UTF16LE,
+		},
+		"When the data is UTF-16BE without a BOM and has non-Latin characters, it's still detected.": {
+			dataInput:    []byte{0x00, 'a', 0x00, ' ', 0x00, '#', 0x00, ' ', 0x65, 0xE5, 0x67, 0x2C, 0x00, '\n'},
+			wantContent:  "a # 日本\n",
+			wantEncoding: text.UTF16BE,
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			input, err := text.Decode(tc.dataInput)
+
+			// Assert.
+			assert.Equalf(t, err, nil, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: <nil>\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, err)
+
+			assert.Equalf(t, input.Content, tc.wantContent, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %q\033[0m\n"+
+				"\033[31mActual:   %q\033[0m\n\n", tcName, tc.wantContent, input.Content)
+
+			assert.Equalf(t, input.Encoding, tc.wantEncoding, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.wantEncoding, input.Encoding)
+
+			// Act.
+			data, err := input.Encode()
+
+			// Assert.
+			assert.Equalf(t, err, nil, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: <nil>\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, err)
+
+			assert.Equalf(t, string(data), string(tc.dataInput), "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: % X\033[0m\n"+
+				"\033[31mActual:   % X\033[0m\n\n", tcName, tc.dataInput, data)
+		})
+	}
+}
+
+// UT: Verify that data that can't be transcoded without loss is rejected.
+func Test_Decode_Invalid(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		dataInput []byte
+	}{
+		"When UTF-16 data has an odd length, an error is returned.": {
+			dataInput: []byte{0xFF, 0xFE, 'A', 0x00, 'B'},
+		},
+		"When UTF-16 data contains an unpaired high surrogate, an error is returned.": {
+			dataInput: []byte{0xFF, 0xFE, 0x3D, 0xD8, 'A', 0x00},
+		},
+		"When UTF-16 data ends with a high surrogate, an error is returned.": {
+			dataInput: []byte{0xFF, 0xFE, 'A', 0x00, 0x3D, 0xD8},
+		},
+		"When UTF-16 data contains an unpaired low surrogate, an error is returned.": {
+			dataInput: []byte{0xFF, 0xFE, 0x80, 0xDE, 'A', 0x00},
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			_, err := text.Decode(tc.dataInput)
+
+			// Assert.
+			assert.Equalf(t, errors.Is(err, text.ErrInvalidEncoding), true, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, text.ErrInvalidEncoding, err)
+		})
+	}
+}
+
+// UT: Verify that content which isn't valid UTF-8 can't be encoded as UTF-16.
+func TestInput_Encode(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	input := text.Input{Content: "A\xFFB", Encoding: text.UTF16LE}
+
+	// Act.
+	_, err := input.Encode()
+
+	// Assert.
+	assert.Equalf(t, errors.Is(err, text.ErrInvalidEncoding), true, "\n\n"+
+		"UT Name:  When content that isn't valid UTF-8 is encoded as UTF-16, an error is returned.\n"+
+		"\033[32mExpected: %v\033[0m\n"+
+		"\033[31mActual:   %v\033[0m\n\n", text.ErrInvalidEncoding, err)
+}
+
+// UT: Verify that offsets in the content are mapped onto offsets in the original data.
+func TestInput_SourceOffset(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		encodingInput text.Encoding
+		offsetInput   int
+		want          int
+	}{
+		"When the input is plain UTF-8, the offset is unchanged.": {
+			encodingInput: text.UTF8,
+			offsetInput:   5,
+			want:          5,
+		},
+		"When the input is UTF-8 with a BOM, the offset is moved past the BOM.": {
+			encodingInput: text.UTF8BOM,
+			offsetInput:   5,
+			want:          8,
+		},
+		"When the input is UTF-16 without a BOM, every code unit takes 2 bytes.": {
+			encodingInput: text.UTF16LE,
+			offsetInput:   1,
+			want:          2,
+		},
+		"When the input is UTF-16 with a BOM, an astral-plane character takes 4 bytes.": {
+			encodingInput: text.UTF16BEBOM,
+			offsetInput:   5,
+			want:          8,
+		},
+		"When the input is UTF-16, a BMP character takes 2 bytes regardless of its UTF-8 length.": {
+			encodingInput: text.UTF16LE,
+			offsetInput:   8,
+			want:          8,
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			input := text.Input{Content: "A🚀日C", Encoding: tc.encodingInput}
+
+			// Act.
+			got := input.SourceOffset(tc.offsetInput)
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d\033[0m\n"+
+				"\033[31mActual:   %d\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Verify that byte sequences which aren't valid UTF-8 are reported.
+func TestInput_Validate(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		contentInput string
+		want         []text.Span
+	}{
+		"When the content is valid UTF-8, no spans are returned.": {
+			contentInput: "na\u00EFve \U0001F600",
+			want:         nil,
+		},
+		"When the content contains a stray continuation byte, its span is returned.": {
+			contentInput: "a\x80b",
+			want:         []text.Span{newSpan(1, 2)},
+		},
+		"When the content contains a truncated sequence, it's reported as a single span.": {
+			contentInput: "a\xE2\x82b",
+			want:         []text.Span{newSpan(1, 3)},
+		},
+		"When the content contains multiple invalid sequences, each is reported.": {
+			contentInput: "\xFFok\xC0\xAF",
+			want:         []text.Span{newSpan(0, 1), newSpan(3, 5)},
+		},
+		"When the content contains an encoded replacement character, it's valid.": {
+			contentInput: "\uFFFD",
+			want:         nil,
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			input := newInput(tc.contentInput)
+
+			// Act.
+			got := input.Validate()
+
+			// Assert.
+			assert.Equalf(t, len(got), len(tc.want), "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, tc.want, got)
+
+			for idx := range min(len(got), len(tc.want)) {
+				assert.Equalf(t, got[idx], tc.want[idx], "\n\n"+
+					"UT Name:  %s\n"+
+					"\033[32mExpected: #%d - %s\033[0m\n"+
+					"\033[31mActual:   #%d - %s\033[0m\n\n", tcName, idx, tc.want[idx], idx, got[idx])
+			}
+		})
+	}
+}
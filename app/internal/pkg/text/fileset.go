@@ -0,0 +1,223 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package text provides foundational types for managing and measuring text content.
+//
+// It serves as a coordinate system for UTF-8 encoded strings, defining how raw byte segments [Span]s map to
+// human-readable dimensions like line and columns.
+package text
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Pos is a compact encoding of a position in a [FileSet].
+//
+// A Pos combines the identity of a [File] and a byte offset inside of it into a single integer, which can be turned
+// into a human-readable [Position] with [FileSet.Position]. The zero value, [NoPos], doesn't refer to any position.
+type Pos int
+
+// NoPos is the zero value of [Pos]; it isn't associated with any file.
+const NoPos Pos = 0
+
+// IsValid reports whether the position refers to a file.
+func (p Pos) IsValid() bool {
+	return p != NoPos
+}
+
+// Position is the human-readable form of a [Pos].
+type Position struct {
+	// Filename is the name of the file the position refers to.
+	Filename string
+
+	// Offset is the 0-based byte offset in the file.
+	Offset int
+
+	// Location is the line and column in the file.
+	Location
+}
+
+// IsValid reports whether the position refers to a location.
+func (pos Position) IsValid() bool {
+	return pos.Line > 0
+}
+
+// String returns the string representation of the position.
+//
+// The representation is "file:line:column", "line:column" if the position has no file name, or "-" if the position
+// isn't valid.
+func (pos Position) String() string {
+	switch {
+	case !pos.IsValid():
+		return "-"
+
+	case pos.Filename == "":
+		return pos.Location.String()
+	}
+
+	return fmt.Sprintf("%s:%s", pos.Filename, pos.Location)
+}
+
+// File is an [Input] that's registered in a [FileSet].
+type File struct {
+	name  string
+	base  int
+	input *Input
+}
+
+// Name returns the name under which the file was registered.
+func (file *File) Name() string {
+	return file.name
+}
+
+// Base returns the [Pos] value of the first byte of the file.
+func (file *File) Base() Pos {
+	return Pos(file.base)
+}
+
+// Input returns the content of the file.
+func (file *File) Input() *Input {
+	return file.input
+}
+
+// Pos returns the [Pos] value of the given byte offset in the file.
+//
+// Pos panics if offset is outside the range [0, len(Content)].
+func (file *File) Pos(offset int) Pos {
+	if offset < 0 || offset > len(file.input.Content) {
+		panic("text: file offset out of range")
+	}
+
+	return Pos(file.base + offset)
+}
+
+// Offset returns the byte offset in the file of the given [Pos] value.
+//
+// Offset panics if p doesn't belong to the file.
+func (file *File) Offset(p Pos) int {
+	if !file.contains(p) {
+		panic("text: position outside of file")
+	}
+
+	return int(p) - file.base
+}
+
+// Position returns the human-readable form of the given [Pos] value.
+//
+// Position panics if p doesn't belong to the file.
+func (file *File) Position(p Pos) Position {
+	offset := file.Offset(p)
+
+	return Position{
+		Filename: file.name,
+		Offset:   offset,
+		Location: file.input.LineCol(offset),
+	}
+}
+
+// Reports whether p belongs to the file. The position directly after the last byte is part of the file.
+func (file *File) contains(p Pos) bool {
+	return file.base <= int(p) && int(p) <= file.base+len(file.input.Content)
+}
+
+// FileSet holds a collection of [File]s, each of which occupies its own range of [Pos] values.
+//
+// A FileSet may be used concurrently.
+type FileSet struct {
+	mu    sync.RWMutex
+	base  int
+	files []*File
+}
+
+// NewFileSet initializes an empty [FileSet].
+func NewFileSet() *FileSet {
+	return &FileSet{
+		base: 1,
+	}
+}
+
+// AddFile registers input under the given name and returns the resulting [File].
+//
+// The file occupies the [Pos] values from the current base of the set up to and including the position directly after
+// its last byte.
+func (fs *FileSet) AddFile(name string, input *Input) *File {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	file := &File{
+		name:  name,
+		base:  fs.base,
+		input: input,
+	}
+
+	fs.base += len(input.Content) + 1
+	fs.files = append(fs.files, file)
+
+	return file
+}
+
+// File returns the [File] that contains p, or nil if there's no such file.
+func (fs *FileSet) File(p Pos) *File {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	idx := sort.Search(len(fs.files), func(i int) bool {
+		return fs.files[i].base > int(p)
+	}) - 1
+
+	if idx < 0 || !fs.files[idx].contains(p) {
+		return nil
+	}
+
+	return fs.files[idx]
+}
+
+// Position returns the human-readable form of p.
+//
+// The zero [Position] is returned if p doesn't belong to any file in the set.
+func (fs *FileSet) Position(p Pos) Position {
+	file := fs.File(p)
+
+	if file == nil {
+		return Position{}
+	}
+
+	return file.Position(p)
+}
+
+// Span returns the [File] that contains the positions start and end, together with the [Span] between them.
+//
+// A nil file and the zero [Span] are returned if the positions don't belong to the same file, or if end precedes start.
+func (fs *FileSet) Span(start, end Pos) (*File, Span) {
+	file := fs.File(start)
+
+	if file == nil || !file.contains(end) || end < start {
+		return nil, Span{}
+	}
+
+	return file, Span{Start: file.Offset(start), End: file.Offset(end)}
+}
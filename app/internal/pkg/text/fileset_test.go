@@ -0,0 +1,190 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify the implementation of the "text" package.
+package text_test
+
+import (
+	"testing"
+
+	"github.com/kdeconinck/lens/internal/pkg/assert"
+	"github.com/kdeconinck/lens/internal/pkg/text"
+)
+
+// UT: Get the human-readable representation of a position.
+func Test_PositionString(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		posInput text.Position
+		want     string
+	}{
+		"When the position has a file name, it's displayed as 'File:Line:Column'.": {
+			posInput: text.Position{Filename: "rules.lux", Offset: 12, Location: newLocation(2, 5)},
+			want:     "rules.lux:2:5",
+		},
+		"When the position doesn't have a file name, it's displayed as 'Line:Column'.": {
+			posInput: text.Position{Offset: 12, Location: newLocation(2, 5)},
+			want:     "2:5",
+		},
+		"When the position isn't valid, it's displayed as '-'.": {
+			posInput: text.Position{},
+			want:     "-",
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := tc.posInput.String()
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Verify that positions in a file set are resolved into the file they belong to.
+func TestFileSet_Position(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	fs := text.NewFileSet()
+	first := fs.AddFile("a.lux", &text.Input{Content: "version = 1\n"})
+	second := fs.AddFile("b.lux", &text.Input{Content: "x\ny"})
+
+	for tcName, tc := range map[string]struct {
+		posInput text.Pos
+		want     string
+	}{
+		"When the position is the first byte of the first file, it resolves to '1:1' in that file.": {
+			posInput: first.Pos(0),
+			want:     "a.lux:1:1",
+		},
+		"When the position is the end of the first file, it resolves to the position after its last byte.": {
+			posInput: first.Pos(12),
+			want:     "a.lux:2:1",
+		},
+		"When the position is in the second file, it resolves to a location in that file.": {
+			posInput: second.Pos(2),
+			want:     "b.lux:2:1",
+		},
+		"When the position is 'NoPos', it doesn't resolve.": {
+			posInput: text.NoPos,
+			want:     "-",
+		},
+		"When the position is past the last file, it doesn't resolve.": {
+			posInput: second.Pos(3) + 1,
+			want:     "-",
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := fs.Position(tc.posInput).String()
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Verify that the files in a file set occupy distinct ranges of positions.
+func TestFileSet_AddFile(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	fs := text.NewFileSet()
+	first := fs.AddFile("a.lux", &text.Input{Content: "abc"})
+	second := fs.AddFile("b.lux", &text.Input{Content: "de"})
+
+	// Act.
+	got, want := second.Base(), first.Base()+4
+
+	// Assert.
+	assert.Equalf(t, got, want, "\n\n"+
+		"UT Name:  When a file is added, its base follows the position after the last byte of the previous file.\n"+
+		"\033[32mExpected: %d\033[0m\n"+
+		"\033[31mActual:   %d\033[0m\n\n", want, got)
+
+	assert.Equalf(t, fs.File(second.Pos(1)), second, "\n\n"+
+		"UT Name:  When looking up a position, the file that contains it is returned.\n"+
+		"\033[32mExpected: %s\033[0m\n"+
+		"\033[31mActual:   %s\033[0m\n\n", second.Name(), fs.File(second.Pos(1)).Name())
+
+	assert.Equalf(t, first.Offset(first.Pos(2)), 2, "\n\n"+
+		"UT Name:  When converting an offset into a position and back, the offset is retained.\n"+
+		"\033[32mExpected: %d\033[0m\n"+
+		"\033[31mActual:   %d\033[0m\n\n", 2, first.Offset(first.Pos(2)))
+}
+
+// UT: Verify that a pair of positions is translated into a span in a single file.
+func TestFileSet_Span(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	fs := text.NewFileSet()
+	first := fs.AddFile("a.lux", &text.Input{Content: "abc"})
+	second := fs.AddFile("b.lux", &text.Input{Content: "defgh"})
+
+	t.Run("When both positions are in the same file, the span between them is returned.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Act.
+		gotFile, got := fs.Span(second.Pos(1), second.Pos(4))
+		want := newSpan(1, 4)
+
+		// Assert.
+		assert.Equalf(t, gotFile, second, "\n\n"+
+			"UT Name:  When both positions are in the same file, the span between them is returned.\n"+
+			"\033[32mExpected: %p\033[0m\n"+
+			"\033[31mActual:   %p\033[0m\n\n", second, gotFile)
+
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When both positions are in the same file, the span between them is returned.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, got)
+	})
+
+	t.Run("When the positions are in different files, no span is returned.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Act.
+		gotFile, _ := fs.Span(first.Pos(1), second.Pos(1))
+
+		// Assert.
+		assert.Equalf(t, gotFile == nil, true, "\n\n"+
+			"UT Name:  When the positions are in different files, no span is returned.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %p\033[0m\n\n", gotFile)
+	})
+}
@@ -0,0 +1,216 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package text provides foundational types for managing and measuring text content.
+//
+// It serves as a coordinate system for UTF-8 encoded strings, defining how raw byte segments [Span]s map to
+// human-readable dimensions like line and columns.
+package text
+
+import "strconv"
+
+// Indent represents a style of indentation.
+type Indent int
+
+// The different styles of indentation.
+const (
+	// NoIndent is used when an input doesn't contain any indented line.
+	NoIndent Indent = iota
+
+	// Tabs is used for indentation that only consists of tabs.
+	Tabs
+
+	// Spaces is used for indentation that only consists of spaces.
+	Spaces
+)
+
+// Maps an [Indent] to its human-readable name.
+var indentMap = map[Indent]string{
+	NoIndent: "None",
+	Tabs:     "Tabs",
+	Spaces:   "Spaces",
+}
+
+// String returns the string representation of the indentation style.
+func (ind Indent) String() string {
+	value, ok := indentMap[ind]
+
+	if ok {
+		return value
+	}
+
+	return "Unknown(" + strconv.Itoa(int(ind)) + ")"
+}
+
+// Indentation describes the leading whitespace of the lines in an [Input].
+//
+// Lines that are empty or only consist of whitespace are ignored.
+type Indentation struct {
+	// Style is the dominant style of indentation. When 2 styles are used equally often, the one that appears first wins.
+	Style Indent
+
+	// Width is the number of characters of Style that make up a single level of indentation, or 0 if it can't be
+	// inferred.
+	//
+	// It's the most common increase in indentation from one line to the next, which, unlike the greatest common
+	// divisor of all indents, isn't thrown off by a few lines that are aligned instead of indented. The greatest common
+	// divisor is only used when the indentation never increases between lines of the same style.
+	Width int
+
+	// Count holds the number of indented lines per style. Lines with mixed indentation aren't counted.
+	Count map[Indent]int
+
+	// Consistency is the fraction of the indented lines that use Style and a multiple of Width, ranging from 0 to 1.
+	// It's 1 if no line is indented.
+	Consistency float64
+
+	// Mixed holds the spans of the indentation of the lines that mix tabs and spaces, in order of appearance.
+	Mixed []Span
+}
+
+// IsMixed reports whether a line mixes tabs and spaces in its indentation.
+func (ind Indentation) IsMixed() bool {
+	return len(ind.Mixed) > 0
+}
+
+// Indentation analyses the leading whitespace of the lines of the input.
+//
+// The result can be used as a default for a formatter when no indentation has been configured, or to flag lines that
+// don't match the rest of the input.
+func (input *Input) Indentation() Indentation {
+	ind := Indentation{
+		Count:       make(map[Indent]int),
+		Consistency: 1,
+	}
+
+	type indent struct {
+		style Indent
+		size  int
+	}
+
+	var indents []indent
+
+	first := make(map[Indent]int)
+	deltas := map[Indent]map[int]int{Tabs: {}, Spaces: {}}
+	prev := indent{style: NoIndent}
+
+	for line := 1; line <= input.LineCount(); line++ {
+		span := input.LineSpan(line)
+		content := input.Content[span.Start:span.End]
+		size, tabs := 0, 0
+
+		for size < len(content) && (content[size] == ' ' || content[size] == '\t') {
+			if content[size] == '\t' {
+				tabs++
+			}
+
+			size++
+		}
+
+		if size == len(content) {
+			continue
+		}
+
+		cur := indent{style: NoIndent, size: size}
+
+		switch {
+		case size == 0:
+		case tabs == size:
+			cur.style = Tabs
+		case tabs == 0:
+			cur.style = Spaces
+		default:
+			ind.Mixed = append(ind.Mixed, Span{Start: span.Start, End: span.Start + size})
+			prev = cur
+
+			continue
+		}
+
+		if cur.style != NoIndent {
+			if _, ok := first[cur.style]; !ok {
+				first[cur.style] = line
+			}
+
+			ind.Count[cur.style]++
+			indents = append(indents, cur)
+
+			if prev.size == 0 || prev.style == cur.style {
+				if delta := cur.size - prev.size; delta > 0 {
+					deltas[cur.style][delta]++
+				}
+			}
+		}
+
+		prev = cur
+	}
+
+	for _, style := range []Indent{Tabs, Spaces} {
+		if ind.Count[style] == 0 {
+			continue
+		}
+
+		if ind.Style == NoIndent || ind.Count[style] > ind.Count[ind.Style] ||
+			(ind.Count[style] == ind.Count[ind.Style] && first[style] < first[ind.Style]) {
+			ind.Style = style
+		}
+	}
+
+	for delta, n := range deltas[ind.Style] {
+		if n > deltas[ind.Style][ind.Width] || (n == deltas[ind.Style][ind.Width] && delta < ind.Width) {
+			ind.Width = delta
+		}
+	}
+
+	if ind.Width == 0 {
+		for _, cur := range indents {
+			if cur.style == ind.Style {
+				ind.Width = gcd(ind.Width, cur.size)
+			}
+		}
+	}
+
+	if total := len(indents) + len(ind.Mixed); total > 0 {
+		consistent := 0
+
+		for _, cur := range indents {
+			if cur.style == ind.Style && (ind.Width == 0 || cur.size%ind.Width == 0) {
+				consistent++
+			}
+		}
+
+		ind.Consistency = float64(consistent) / float64(total)
+	}
+
+	return ind
+}
+
+// Returns the greatest common divisor of a and b.
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
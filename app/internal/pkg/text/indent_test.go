@@ -0,0 +1,168 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify the implementation of the "text" package.
+package text_test
+
+import (
+	"testing"
+
+	"github.com/kdeconinck/lens/internal/pkg/assert"
+	"github.com/kdeconinck/lens/internal/pkg/text"
+)
+
+// UT: Get the human-readable representation of an indentation style.
+func Test_IndentString(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		indentInput text.Indent
+		want        string
+	}{
+		"When the style is 'NoIndent', it's displayed as 'None'.": {
+			indentInput: text.NoIndent,
+			want:        "None",
+		},
+		"When the style is 'Tabs', it's displayed as 'Tabs'.": {
+			indentInput: text.Tabs,
+			want:        "Tabs",
+		},
+		"When the style is 'Spaces', it's displayed as 'Spaces'.": {
+			indentInput: text.Spaces,
+			want:        "Spaces",
+		},
+		"When the style is unknown, it's displayed as 'Unknown(X)'.": {
+			indentInput: text.Indent(99),
+			want:        "Unknown(99)",
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := tc.indentInput.String()
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Verify that the indentation of an input is analysed.
+func TestInput_Indentation(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		contentInput    string
+		wantStyle       text.Indent
+		wantWidth       int
+		wantConsistency float64
+		wantMixed       []text.Span
+	}{
+		"When the input isn't indented, the style is 'NoIndent'.": {
+			contentInput:    "a\n\n  \nb",
+			wantStyle:       text.NoIndent,
+			wantWidth:       0,
+			wantConsistency: 1,
+		},
+		"When the input is indented with spaces, the style and the width are inferred.": {
+			contentInput:    "a {\n    b {\n        c\n    }\n}",
+			wantStyle:       text.Spaces,
+			wantWidth:       4,
+			wantConsistency: 1,
+		},
+		"When the input is indented with tabs, the width is counted in tabs.": {
+			contentInput:    "a {\n\tb {\n\t\tc\n\t}\n}",
+			wantStyle:       text.Tabs,
+			wantWidth:       1,
+			wantConsistency: 1,
+		},
+		"When a few lines are aligned instead of indented, the width isn't affected.": {
+			contentInput:    "a {\n  b {\n    c\n  }\n  d(1,\n     2)\n}",
+			wantStyle:       text.Spaces,
+			wantWidth:       2,
+			wantConsistency: 0.8,
+		},
+		"When the input mixes styles, the most frequent one wins.": {
+			contentInput:    "a\n\tb\n  c\n  d",
+			wantStyle:       text.Spaces,
+			wantWidth:       2,
+			wantConsistency: 2.0 / 3.0,
+		},
+		"When a line mixes tabs and spaces, its indentation is reported.": {
+			contentInput:    "a\n\tb\n\t c\n \td",
+			wantStyle:       text.Tabs,
+			wantWidth:       1,
+			wantConsistency: 1.0 / 3.0,
+			wantMixed:       []text.Span{newSpan(5, 7), newSpan(9, 11)},
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			input := newInput(tc.contentInput)
+
+			// Act.
+			got := input.Indentation()
+
+			// Assert.
+			assert.Equalf(t, got.Style, tc.wantStyle, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.wantStyle, got.Style)
+
+			assert.Equalf(t, got.Width, tc.wantWidth, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d\033[0m\n"+
+				"\033[31mActual:   %d\033[0m\n\n", tcName, tc.wantWidth, got.Width)
+
+			assert.Equalf(t, got.Consistency, tc.wantConsistency, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %.2f\033[0m\n"+
+				"\033[31mActual:   %.2f\033[0m\n\n", tcName, tc.wantConsistency, got.Consistency)
+
+			assert.Equalf(t, got.IsMixed(), len(tc.wantMixed) > 0, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, tc.wantMixed, got.Mixed)
+
+			assert.Equalf(t, len(got.Mixed), len(tc.wantMixed), "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, tc.wantMixed, got.Mixed)
+
+			for idx, want := range tc.wantMixed {
+				assert.Equalf(t, got.Mixed[idx], want, "\n\n"+
+					"UT Name:  %s\n"+
+					"\033[32mExpected: #%d - %s\033[0m\n"+
+					"\033[31mActual:   #%d - %s\033[0m\n\n", tcName, idx, want, idx, got.Mixed[idx])
+			}
+		})
+	}
+}
@@ -29,41 +29,258 @@
 // human-readable dimensions like line and columns.
 package text
 
-import "unicode/utf8"
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Errors reported when a [Location] doesn't exist in an [Input].
+var (
+	ErrLineOutOfRange   = errors.New("line out of range")
+	ErrColumnOutOfRange = errors.New("column out of range")
+	ErrInvertedSpan     = errors.New("end precedes start")
+)
 
 // Input encapsulates a string.
+//
+// Lines are separated by "\n", "\r\n" or a lone "\r", each of which counts as a single line break. The first
+// line-based query builds a table holding the byte offset at which each line starts. Every subsequent query reuses that
+// table, so an Input must not be copied once it has been used.
 type Input struct {
 	// Content represents the full string.
 	Content string
+
+	// Encoding is the encoding of the data that Content was decoded from. See [Decode].
+	Encoding Encoding
+
+	once  sync.Once
+	lines []int
 }
 
 // LineCol translates a 0-based byte offset into 1-based line and column numbers.
 //
-// LineCol is UTF-8 aware; it treats multibyte characters as a single visual column unit. It iterates through the
-// content to find the position, making it an O(n) operation relative to the offset.
-func (input Input) LineCol(offset int) (loc Location) {
-	loc.Line, loc.Column = 1, 1
+// LineCol is UTF-8 aware; it treats multibyte characters as a single visual column unit. It's a shorthand for
+// [Input.LocationOf] with the zero [Columns].
+func (input *Input) LineCol(offset int) Location {
+	return input.LocationOf(offset, Columns{})
+}
 
+// LocationOf translates a 0-based byte offset into a 1-based line number and a 1-based column measured as described by
+// cols.
+//
+// The line is found with a binary search over the line-start table, making it an O(log n) operation, followed by a walk
+// over the characters that precede the offset on that line. An offset in the middle of a multi-byte character (or of a
+// grapheme cluster when measuring [Display] columns) refers to that character, an offset inside a "\r\n" line break
+// refers to that line break, and an offset past the end of the input refers to its last character.
+func (input *Input) LocationOf(offset int, cols Columns) Location {
 	if offset > len(input.Content) {
 		offset = len(input.Content) - 1
 	}
 
-	// Backtrack if the offset is in the middle of a multi-byte character.
-	for offset > 0 && !utf8.RuneStart(input.Content[offset]) {
-		offset--
+	if offset < 0 {
+		offset = 0
+	}
+
+	line := input.lineIndex(offset)
+	start := input.lineStarts()[line]
+
+	return Location{
+		Line:   line + 1,
+		Column: columnIn(input.Content[start:input.lineEnd(line)], offset-start, cols),
+	}
+}
+
+// Offset translates 1-based line and column numbers into a 0-based byte offset.
+//
+// Offset is the inverse of [Input.LineCol] and a shorthand for [Input.OffsetOf] with the zero [Columns].
+func (input *Input) Offset(loc Location) (int, error) {
+	return input.OffsetOf(loc, Columns{})
+}
+
+// OffsetOf translates a 1-based line number and a 1-based column measured as described by cols into a 0-based byte
+// offset.
+//
+// OffsetOf is the inverse of [Input.LocationOf]. The column directly after the last character of a line is valid and
+// refers to its line break (or the end of the input). A location that lies beyond that, on a line that doesn't exist,
+// or in the middle of a character that spans multiple columns, results in an error wrapping [ErrLineOutOfRange] or
+// [ErrColumnOutOfRange].
+func (input *Input) OffsetOf(loc Location, cols Columns) (int, error) {
+	if loc.Line < 1 || loc.Line > input.LineCount() {
+		return 0, fmt.Errorf("%w: %s (the input has %d lines)", ErrLineOutOfRange, loc, input.LineCount())
+	}
+
+	span := input.LineSpan(loc.Line)
+	offset, err := offsetIn(input.Content[span.Start:span.End], loc, cols)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return span.Start + offset, nil
+}
+
+// SpanOf translates a pair of locations into the [Span] that starts at from and ends at to.
+//
+// Both locations are validated by [Input.Offset]. An error wrapping [ErrInvertedSpan] is returned if to precedes from.
+func (input *Input) SpanOf(from, to Location) (Span, error) {
+	start, err := input.Offset(from)
+
+	if err != nil {
+		return Span{}, err
 	}
 
-	currentByte := 0
+	end, err := input.Offset(to)
+
+	if err != nil {
+		return Span{}, err
+	}
+
+	if end < start {
+		return Span{}, fmt.Errorf("%w: %s..%s", ErrInvertedSpan, from, to)
+	}
+
+	return Span{Start: start, End: end}, nil
+}
+
+// LineCount returns the number of lines in the input.
+//
+// An empty input consists of a single, empty line, and content that ends with a line break has an empty last line.
+func (input *Input) LineCount() int {
+	return len(input.lineStarts())
+}
+
+// Line returns the content of the 1-based line n, without its line break.
+//
+// Line panics if n is outside the range [1, LineCount()].
+func (input *Input) Line(n int) string {
+	span := input.LineSpan(n)
+
+	return input.Content[span.Start:span.End]
+}
+
+// LineSpan returns the [Span] covering the 1-based line n, without its line break.
+//
+// LineSpan panics if n is outside the range [1, LineCount()].
+func (input *Input) LineSpan(n int) Span {
+	lines := input.lineStarts()
+
+	if n < 1 || n > len(lines) {
+		panic("text: line number out of range")
+	}
 
-	for _, r := range input.Content {
-		if currentByte >= offset {
+	return Span{
+		Start: lines[n-1],
+		End:   input.lineEnd(n - 1),
+	}
+}
+
+// Returns the table with the byte offset at which each line starts, building it on first use.
+func (input *Input) lineStarts() []int {
+	input.once.Do(func() {
+		input.lines = append(input.lines, 0)
+
+		for offset := 0; ; {
+			idx := strings.IndexAny(input.Content[offset:], "\r\n")
+
+			if idx < 0 {
+				break
+			}
+
+			offset += idx + 1
+
+			if input.Content[offset-1] == '\r' && offset < len(input.Content) && input.Content[offset] == '\n' {
+				offset++
+			}
+
+			input.lines = append(input.lines, offset)
+		}
+	})
+
+	return input.lines
+}
+
+// Returns the byte offset at which the line with the given 0-based index ends, excluding its line break.
+func (input *Input) lineEnd(line int) int {
+	lines := input.lineStarts()
+
+	if line+1 < len(lines) {
+		return lines[line+1] - input.lineBreak(line+1).Len()
+	}
+
+	return len(input.Content)
+}
+
+// Returns the span of the line break that precedes the line with the given 0-based index, which must be > 0.
+func (input *Input) lineBreak(line int) Span {
+	end := input.lineStarts()[line]
+
+	if end >= 2 && input.Content[end-2:end] == "\r\n" {
+		return Span{Start: end - 2, End: end}
+	}
+
+	return Span{Start: end - 1, End: end}
+}
+
+// Returns the 0-based index of the line that contains the given byte offset.
+func (input *Input) lineIndex(offset int) int {
+	lines := input.lineStarts()
+
+	return sort.Search(len(lines), func(i int) bool {
+		return lines[i] > offset
+	}) - 1
+}
+
+// Returns the 1-based column, measured as described by cols, of the given byte offset in line.
+//
+// An offset in the middle of a character refers to that character, and an offset past the end of the line refers to
+// the position directly after its last character.
+func columnIn(line string, offset int, cols Columns) int {
+	column := 1
+
+	for pos := 0; pos < offset && pos < len(line); {
+		size, width := cols.next(line[pos:], column)
+
+		if pos+size > offset {
 			break
 		}
 
-		loc.advance(r)
+		column += width
+		pos += size
+	}
+
+	return column
+}
+
+// Returns the byte offset in line of the column of loc, which is measured as described by cols.
+//
+// The column directly after the last character of the line is valid and refers to the end of the line.
+func offsetIn(line string, loc Location, cols Columns) (int, error) {
+	if loc.Column < 1 {
+		return 0, fmt.Errorf("%w: %s", ErrColumnOutOfRange, loc)
+	}
+
+	column := 1
+
+	for offset := 0; offset < len(line); {
+		if column == loc.Column {
+			return offset, nil
+		}
+
+		if column > loc.Column {
+			return 0, fmt.Errorf("%w: %s (splits a character)", ErrColumnOutOfRange, loc)
+		}
+
+		size, width := cols.next(line[offset:], column)
+		column += width
+		offset += size
+	}
 
-		currentByte += len(string(r))
+	if column != loc.Column {
+		return 0, fmt.Errorf("%w: %s (line %d has %d columns)", ErrColumnOutOfRange, loc, loc.Line, column)
 	}
 
-	return loc
+	return len(line), nil
 }
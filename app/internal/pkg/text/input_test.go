@@ -27,6 +27,7 @@
 package text_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/kdeconinck/lens/internal/pkg/assert"
@@ -117,6 +118,70 @@ func TestInput_LineCol(t *testing.T) {
 			"\033[31mActual:   %s\033[0m\n\n", want, got)
 	})
 
+	t.Run("When the offset is at the end, the representation matches the position after the last character.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		input := newInput("Hello\nWorld")
+
+		// Act.
+		got, want := input.LineCol(11), newLocation(2, 6)
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When the offset is at the end, the representation matches the position after the last character.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, got)
+	})
+
+	t.Run("When the input uses 'CRLF' line breaks, each line break counts once.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		input := newInput("Hello\r\nWorld")
+
+		// Act.
+		got, want := input.LineCol(9), newLocation(2, 3)
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When the input uses 'CRLF' line breaks, each line break counts once.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, got)
+	})
+
+	t.Run("When the offset points inside a 'CRLF' line break, the column of the line break is returned.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		input := newInput("Hello\r\nWorld")
+
+		// Act.
+		got, want := input.LineCol(6), newLocation(1, 6)
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When the offset points inside a 'CRLF' line break, the column of the line break is returned.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, got)
+	})
+
+	t.Run("When the input uses lone 'CR' line breaks, each of them starts a new line.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		input := newInput("Hello\rWorld\rAgain")
+
+		// Act.
+		got, want := input.LineCol(14), newLocation(3, 3)
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When the input uses lone 'CR' line breaks, each of them starts a new line.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, got)
+	})
+
 	t.Run("An offset can be used to handle a multi-byte character.", func(t *testing.T) {
 		t.Parallel() // Enable parallel execution.
 
@@ -135,6 +200,304 @@ func TestInput_LineCol(t *testing.T) {
 	})
 }
 
+// UT: Verify that line and column coordinates are correctly translated into byte offsets.
+func TestInput_Offset(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		locInput text.Location
+		want     int
+		wantErr  error
+	}{
+		"When the location is '1:1', the offset is 0.": {
+			locInput: newLocation(1, 1),
+			want:     0,
+		},
+		"When the location is in the middle of a line, the offset matches.": {
+			locInput: newLocation(2, 3),
+			want:     8,
+		},
+		"When the location follows a multi-byte character, the offset skips all its bytes.": {
+			locInput: newLocation(3, 3),
+			want:     17,
+		},
+		"When the location is directly after the last character of a line, the offset of the line break is returned.": {
+			locInput: newLocation(1, 6),
+			want:     5,
+		},
+		"When the location is directly after the last character of the input, the length of the input is returned.": {
+			locInput: newLocation(3, 4),
+			want:     18,
+		},
+		"When the column is past the end of the line, an error is returned.": {
+			locInput: newLocation(1, 7),
+			wantErr:  text.ErrColumnOutOfRange,
+		},
+		"When the column is 0, an error is returned.": {
+			locInput: newLocation(1, 0),
+			wantErr:  text.ErrColumnOutOfRange,
+		},
+		"When the line is past the end of the input, an error is returned.": {
+			locInput: newLocation(4, 1),
+			wantErr:  text.ErrLineOutOfRange,
+		},
+		"When the line is 0, an error is returned.": {
+			locInput: newLocation(0, 1),
+			wantErr:  text.ErrLineOutOfRange,
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			input := newInput("Hello\nWorld\nA🚀C")
+
+			// Act.
+			got, err := input.Offset(tc.locInput)
+
+			// Assert.
+			assert.Equalf(t, errors.Is(err, tc.wantErr), true, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, tc.wantErr, err)
+
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d\033[0m\n"+
+				"\033[31mActual:   %d\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Verify that a pair of locations is correctly translated into a span.
+func TestInput_SpanOf(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		fromInput text.Location
+		toInput   text.Location
+		want      text.Span
+		wantErr   error
+	}{
+		"When both locations are valid, the span between them is returned.": {
+			fromInput: newLocation(1, 2),
+			toInput:   newLocation(2, 3),
+			want:      newSpan(1, 8),
+		},
+		"When both locations are equal, an empty span is returned.": {
+			fromInput: newLocation(2, 1),
+			toInput:   newLocation(2, 1),
+			want:      newSpan(6, 6),
+		},
+		"When the start location is invalid, an error is returned.": {
+			fromInput: newLocation(1, 9),
+			toInput:   newLocation(2, 1),
+			wantErr:   text.ErrColumnOutOfRange,
+		},
+		"When the end location is invalid, an error is returned.": {
+			fromInput: newLocation(1, 1),
+			toInput:   newLocation(3, 1),
+			wantErr:   text.ErrLineOutOfRange,
+		},
+		"When the end location precedes the start location, an error is returned.": {
+			fromInput: newLocation(2, 1),
+			toInput:   newLocation(1, 1),
+			wantErr:   text.ErrInvertedSpan,
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			input := newInput("Hello\nWorld")
+
+			// Act.
+			got, err := input.SpanOf(tc.fromInput, tc.toInput)
+
+			// Assert.
+			assert.Equalf(t, errors.Is(err, tc.wantErr), true, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, tc.wantErr, err)
+
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Verify that the number of lines in an input is reported correctly.
+func TestInput_LineCount(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		contentInput string
+		want         int
+	}{
+		"When the input is empty, it consists of a single line.": {
+			contentInput: "",
+			want:         1,
+		},
+		"When the input doesn't contain a newline, it consists of a single line.": {
+			contentInput: "Hello",
+			want:         1,
+		},
+		"When the input contains a newline, it consists of 2 lines.": {
+			contentInput: "Hello\nWorld",
+			want:         2,
+		},
+		"When the input ends with a newline, the last (empty) line is counted.": {
+			contentInput: "Hello\nWorld\n",
+			want:         3,
+		},
+		"When the input contains 'CRLF' line breaks, each of them counts as a single line break.": {
+			contentInput: "Hello\r\nWorld\r\n",
+			want:         3,
+		},
+		"When the input contains lone 'CR' line breaks, each of them counts as a line break.": {
+			contentInput: "Hello\rWorld\r\rAgain",
+			want:         4,
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			input := newInput(tc.contentInput)
+
+			// Act.
+			got := input.LineCount()
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d\033[0m\n"+
+				"\033[31mActual:   %d\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Verify that the content of a single line can be retrieved.
+func TestInput_Line(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		lineInput int
+		want      string
+	}{
+		"When requesting the first line, its content is returned without the line break.": {
+			lineInput: 1,
+			want:      "Hello",
+		},
+		"When requesting an empty line, an empty string is returned.": {
+			lineInput: 2,
+			want:      "",
+		},
+		"When requesting the last line, its content is returned.": {
+			lineInput: 3,
+			want:      "A🚀C",
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			input := newInput("Hello\n\nA🚀C")
+
+			// Act.
+			got := input.Line(tc.lineInput)
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Verify that the span of a single line can be retrieved.
+func TestInput_LineSpan(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When requesting a line, its span excludes the line break.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		input := newInput("Hello\nWorld\n")
+
+		// Act.
+		got, want := input.LineSpan(2), newSpan(6, 11)
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When requesting a line, its span excludes the line break.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, got)
+	})
+
+	t.Run("When requesting a line that ends with 'CRLF', its span excludes both characters.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		input := newInput("Hello\r\nWorld")
+
+		// Act.
+		got, want := input.LineSpan(1), newSpan(0, 5)
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When requesting a line that ends with 'CRLF', its span excludes both characters.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, got)
+	})
+
+	t.Run("When requesting the last (empty) line, an empty span at the end is returned.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		input := newInput("Hello\nWorld\n")
+
+		// Act.
+		got, want := input.LineSpan(3), newSpan(12, 12)
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When requesting the last (empty) line, an empty span at the end is returned.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, got)
+	})
+
+	t.Run("When requesting a line that doesn't exist, a panic is raised.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		input := newInput("Hello")
+
+		// Act.
+		got, want := panics(func() { input.LineSpan(2) }), true
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When requesting a line that doesn't exist, a panic is raised.\n"+
+			"\033[32mExpected: %t\033[0m\n"+
+			"\033[31mActual:   %t\033[0m\n\n", want, got)
+	})
+}
+
+// Returns true if fn panics, false otherwise.
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		panicked = recover() != nil
+	}()
+
+	fn()
+
+	return false
+}
+
 // Returns a new input with the given content.
 func newInput(content string) text.Input {
 	return text.Input{
@@ -0,0 +1,156 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package text provides foundational types for managing and measuring text content.
+//
+// It serves as a coordinate system for UTF-8 encoded strings, defining how raw byte segments [Span]s map to
+// human-readable dimensions like line and columns.
+package text
+
+import "strconv"
+
+// Newline represents a style of line break.
+type Newline int
+
+// The different styles of line break.
+const (
+	// NoNewline is used when an input doesn't contain any line break.
+	NoNewline Newline = iota
+
+	// LF is the Unix style line break ("\n").
+	LF
+
+	// CRLF is the Windows style line break ("\r\n").
+	CRLF
+
+	// CR is the classic Mac OS style line break ("\r").
+	CR
+)
+
+// Maps a [Newline] to its human-readable name.
+var newlineMap = map[Newline]string{
+	NoNewline: "None",
+	LF:        "LF",
+	CRLF:      "CRLF",
+	CR:        "CR",
+}
+
+// Maps a [Newline] to the characters that make up the line break.
+var newlineSequence = map[Newline]string{
+	LF:   "\n",
+	CRLF: "\r\n",
+	CR:   "\r",
+}
+
+// String returns the string representation of the newline style.
+func (nl Newline) String() string {
+	value, ok := newlineMap[nl]
+
+	if ok {
+		return value
+	}
+
+	return "Unknown(" + strconv.Itoa(int(nl)) + ")"
+}
+
+// Sequence returns the characters that make up a line break of this style.
+//
+// An empty string is returned for [NoNewline].
+func (nl Newline) Sequence() string {
+	return newlineSequence[nl]
+}
+
+// LineEnding describes the line breaks that are used in an [Input].
+type LineEnding struct {
+	// Style is the dominant style of line break. When 2 styles are used equally often, the one that appears first wins.
+	Style Newline
+
+	// Count holds the number of line breaks per style.
+	Count map[Newline]int
+
+	// Mixed holds the spans of the line breaks that don't match Style, in order of appearance.
+	Mixed []Span
+}
+
+// IsMixed reports whether more than one style of line break is used.
+func (le LineEnding) IsMixed() bool {
+	return len(le.Mixed) > 0
+}
+
+// LineEnding analyses the line breaks of the input.
+//
+// The result can be used to normalise the line breaks to the dominant style, or to preserve it when new lines are
+// inserted.
+func (input *Input) LineEnding() LineEnding {
+	le := LineEnding{
+		Count: make(map[Newline]int),
+	}
+
+	first := make(map[Newline]int)
+
+	for line := 1; line < input.LineCount(); line++ {
+		nl := input.newline(line)
+
+		if _, ok := first[nl]; !ok {
+			first[nl] = line
+		}
+
+		le.Count[nl]++
+	}
+
+	for _, nl := range []Newline{LF, CRLF, CR} {
+		if le.Count[nl] == 0 {
+			continue
+		}
+
+		if le.Style == NoNewline || le.Count[nl] > le.Count[le.Style] ||
+			(le.Count[nl] == le.Count[le.Style] && first[nl] < first[le.Style]) {
+			le.Style = nl
+		}
+	}
+
+	for line := 1; line < input.LineCount(); line++ {
+		if input.newline(line) != le.Style {
+			le.Mixed = append(le.Mixed, input.lineBreak(line))
+		}
+	}
+
+	return le
+}
+
+// Returns the style of the line break that precedes the line with the given 0-based index, which must be > 0.
+func (input *Input) newline(line int) Newline {
+	span := input.lineBreak(line)
+
+	switch {
+	case span.Len() == 2:
+		return CRLF
+
+	case input.Content[span.Start] == '\r':
+		return CR
+	}
+
+	return LF
+}
@@ -0,0 +1,174 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify the implementation of the "text" package.
+package text_test
+
+import (
+	"testing"
+
+	"github.com/kdeconinck/lens/internal/pkg/assert"
+	"github.com/kdeconinck/lens/internal/pkg/text"
+)
+
+// UT: Get the human-readable representation of a newline style.
+func Test_NewlineString(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		nlInput      text.Newline
+		want         string
+		wantSequence string
+	}{
+		"When the style is 'NoNewline' it's displayed as 'None'.": {
+			nlInput:      text.NoNewline,
+			want:         "None",
+			wantSequence: "",
+		},
+		"When the style is 'LF' it's displayed as 'LF'.": {
+			nlInput:      text.LF,
+			want:         "LF",
+			wantSequence: "\n",
+		},
+		"When the style is 'CRLF' it's displayed as 'CRLF'.": {
+			nlInput:      text.CRLF,
+			want:         "CRLF",
+			wantSequence: "\r\n",
+		},
+		"When the style is 'CR' it's displayed as 'CR'.": {
+			nlInput:      text.CR,
+			want:         "CR",
+			wantSequence: "\r",
+		},
+		"When the style is NOT known it's displayed as 'Unknown(xxx)'.": {
+			nlInput:      text.Newline(100),
+			want:         "Unknown(100)",
+			wantSequence: "",
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got, gotSequence := tc.nlInput.String(), tc.nlInput.Sequence()
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.want, got)
+
+			assert.Equalf(t, gotSequence, tc.wantSequence, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %q\033[0m\n"+
+				"\033[31mActual:   %q\033[0m\n\n", tcName, tc.wantSequence, gotSequence)
+		})
+	}
+}
+
+// UT: Verify that the line breaks of an input are analysed.
+func TestInput_LineEnding(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		contentInput string
+		wantStyle    text.Newline
+		wantCount    map[text.Newline]int
+		wantMixed    []text.Span
+	}{
+		"When the input doesn't contain a line break, the style is 'NoNewline'.": {
+			contentInput: "Hello",
+			wantStyle:    text.NoNewline,
+			wantCount:    map[text.Newline]int{},
+		},
+		"When the input only contains 'LF' line breaks, the style is 'LF'.": {
+			contentInput: "A\nB\n",
+			wantStyle:    text.LF,
+			wantCount:    map[text.Newline]int{text.LF: 2},
+		},
+		"When the input only contains 'CRLF' line breaks, the style is 'CRLF'.": {
+			contentInput: "A\r\nB\r\n",
+			wantStyle:    text.CRLF,
+			wantCount:    map[text.Newline]int{text.CRLF: 2},
+		},
+		"When the input only contains 'CR' line breaks, the style is 'CR'.": {
+			contentInput: "A\rB\r",
+			wantStyle:    text.CR,
+			wantCount:    map[text.Newline]int{text.CR: 2},
+		},
+		"When the input contains mixed line breaks, the most frequent style wins and the others are reported.": {
+			contentInput: "A\nB\r\nC\r\nD\rE",
+			wantStyle:    text.CRLF,
+			wantCount:    map[text.Newline]int{text.LF: 1, text.CRLF: 2, text.CR: 1},
+			wantMixed:    []text.Span{newSpan(1, 2), newSpan(9, 10)},
+		},
+		"When 2 styles are used equally often, the one that appears first wins.": {
+			contentInput: "A\r\nB\nC",
+			wantStyle:    text.CRLF,
+			wantCount:    map[text.Newline]int{text.LF: 1, text.CRLF: 1},
+			wantMixed:    []text.Span{newSpan(4, 5)},
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			input := newInput(tc.contentInput)
+
+			// Act.
+			got := input.LineEnding()
+
+			// Assert.
+			assert.Equalf(t, got.Style, tc.wantStyle, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.wantStyle, got.Style)
+
+			for _, nl := range []text.Newline{text.LF, text.CRLF, text.CR} {
+				assert.Equalf(t, got.Count[nl], tc.wantCount[nl], "\n\n"+
+					"UT Name:  %s\n"+
+					"\033[32mExpected: %d x %s\033[0m\n"+
+					"\033[31mActual:   %d x %s\033[0m\n\n", tcName, tc.wantCount[nl], nl, got.Count[nl], nl)
+			}
+
+			assert.Equalf(t, got.IsMixed(), len(tc.wantMixed) > 0, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, tc.wantMixed, got.Mixed)
+
+			assert.Equalf(t, len(got.Mixed), len(tc.wantMixed), "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, tc.wantMixed, got.Mixed)
+
+			for idx, want := range tc.wantMixed {
+				assert.Equalf(t, got.Mixed[idx], want, "\n\n"+
+					"UT Name:  %s\n"+
+					"\033[32mExpected: #%d - %s\033[0m\n"+
+					"\033[31mActual:   #%d - %s\033[0m\n\n", tcName, idx, want, idx, got.Mixed[idx])
+			}
+		})
+	}
+}
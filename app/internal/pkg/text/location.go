@@ -36,7 +36,8 @@ type Location struct {
 	// Line is the 1-based line number.
 	Line int
 
-	// Column is the 1-based column number, representing the visual position within the line-based UTF-8 characters.
+	// Column is the 1-based column number, measured in the [Unit] that was requested when the location was created.
+	// Unless stated otherwise, that's the number of UTF-8 characters that precede the position on its line, plus one.
 	Column int
 }
 
@@ -44,16 +45,3 @@ type Location struct {
 func (loc Location) String() string {
 	return fmt.Sprintf("%d:%d", loc.Line, loc.Column)
 }
-
-// Updates the Location based on the provided rune.
-//
-// If the rune is a newline ('\n'), it increments the line count and resets the column to 1.
-// For all other characters, it increments the column count.
-func (loc *Location) advance(r rune) {
-	if r == '\n' {
-		loc.Line += 1
-		loc.Column = 1
-	} else {
-		loc.Column++
-	}
-}
@@ -0,0 +1,496 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package text provides foundational types for managing and measuring text content.
+//
+// It serves as a coordinate system for UTF-8 encoded strings, defining how raw byte segments [Span]s map to
+// human-readable dimensions like line and columns.
+package text
+
+import (
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The number of lines that are shown between the first and the last line of a multi-line label before they're elided.
+const maxContextLines = 3
+
+// The ANSI escape sequences that are used when rendering with colour.
+const (
+	ansiReset  = "\033[0m"
+	ansiBold   = "\033[1m"
+	ansiRed    = "\033[1;31m"
+	ansiYellow = "\033[1;33m"
+	ansiBlue   = "\033[1;34m"
+	ansiCyan   = "\033[1;36m"
+)
+
+// Maps the level of a [Snippet] to the colour in which it's rendered.
+var levelColours = map[string]string{
+	"error":   ansiRed,
+	"warning": ansiYellow,
+	"note":    ansiCyan,
+	"help":    ansiCyan,
+}
+
+// Label attaches a message to a region of an [Input].
+type Label struct {
+	// Span is the region that the label points at.
+	Span Span
+
+	// Message describes what's wrong with the region. It may be empty.
+	Message string
+
+	// Primary marks the label that points at the cause of a problem. Primary labels are underlined with '^', secondary
+	// labels, which provide additional context, with '-'.
+	Primary bool
+}
+
+// Snippet is a message, together with the labelled regions of an [Input] that it's about.
+type Snippet struct {
+	// Level is the kind of message, such as "error" or "warning". It's rendered in front of the title.
+	Level string
+
+	// Title is the message itself.
+	Title string
+
+	// Name is the name of the input, which is shown next to the position of the snippet.
+	Name string
+
+	// Labels are the regions of the input that are shown.
+	Labels []Label
+}
+
+// Renderer prints [Snippet]s as an excerpt of the source with a gutter holding line numbers, and with every label
+// underlined below the line it points at.
+//
+// The output looks like this:
+//
+//	error: Unclosed string literal.
+//	 --> rules.lux:2:12
+//	  |
+//	2 | extension: ".cs {
+//	  |            ^^^^^ The string starts here.
+type Renderer struct {
+	// Colour enables ANSI escape sequences in the output.
+	Colour bool
+
+	// TabWidth is the distance between 2 tab stops. Tabs are replaced with spaces so that the underlines line up. A
+	// value <= 0 selects [DefaultTabWidth].
+	TabWidth int
+}
+
+// A label whose position has been resolved into display columns.
+type placedLabel struct {
+	Label
+
+	startLine, startCol int // Where the label starts.
+	endLine, endCol     int // Where the label ends; exclusive for single-line labels, inclusive otherwise.
+	depth               int // The column in the margin used by a multi-line label.
+}
+
+// Reports whether the label spans more than a single line.
+func (label placedLabel) multiline() bool {
+	return label.endLine > label.startLine
+}
+
+// Returns the marker used to underline the label.
+func (label placedLabel) marker() string {
+	if label.Primary {
+		return "^"
+	}
+
+	return "-"
+}
+
+// Render writes snippet, with its labels resolved against input, to w.
+//
+// The position shown in the header is that of the first primary label, or of the first label if there's no primary
+// one. An error is returned if a label doesn't fit in input, or if w can't be written to.
+func (r Renderer) Render(w io.Writer, input *Input, snippet Snippet) error {
+	for _, label := range snippet.Labels {
+		if err := label.Span.Validate(input); err != nil {
+			return err
+		}
+	}
+
+	labels := r.place(input, snippet.Labels)
+	lines := displayedLines(labels)
+	layout := layout{
+		renderer: r,
+		labels:   labels,
+	}
+
+	if len(lines) > 0 {
+		layout.gutter = len(strconv.Itoa(lines[len(lines)-1]))
+	}
+
+	for _, label := range labels {
+		if label.multiline() {
+			layout.margin = max(layout.margin, label.depth+2)
+		}
+	}
+
+	layout.writeHeader(input, snippet)
+
+	for idx, line := range lines {
+		if idx > 0 && line > lines[idx-1]+1 {
+			layout.writeRow("...", nil, "", "")
+		}
+
+		layout.writeLine(input, line)
+	}
+
+	_, err := io.WriteString(w, layout.out.String())
+
+	return err
+}
+
+// Returns the labels with their positions resolved into display columns.
+func (r Renderer) place(input *Input, labels []Label) []placedLabel {
+	cols := Columns{Unit: Display, TabWidth: r.TabWidth}
+	placed := make([]placedLabel, 0, len(labels))
+	depth := 0
+
+	for _, label := range labels {
+		start := input.LocationOf(label.Span.Start, cols)
+		end := input.LocationOf(label.Span.End, cols)
+		pl := placedLabel{
+			Label:     label,
+			startLine: start.Line,
+			startCol:  start.Column,
+			endLine:   start.Line,
+			endCol:    end.Column,
+		}
+
+		if !label.Span.IsEmpty() {
+			last := input.LocationOf(label.Span.End-1, cols)
+
+			switch {
+			case last.Line > start.Line:
+				pl.endLine, pl.endCol = last.Line, last.Column
+				pl.depth = depth
+				depth++
+
+			case end.Line > start.Line:
+				pl.endCol = last.Column + 1 // The label ends with a line break.
+			}
+		}
+
+		if !pl.multiline() && pl.endCol <= pl.startCol {
+			pl.endCol = pl.startCol + 1
+		}
+
+		placed = append(placed, pl)
+	}
+
+	return placed
+}
+
+// Returns the line with its tabs expanded to spaces.
+func (r Renderer) expand(line string) string {
+	if !strings.Contains(line, "\t") {
+		return line
+	}
+
+	cols := Columns{Unit: Display, TabWidth: r.TabWidth}
+
+	var sb strings.Builder
+
+	for column := 1; len(line) > 0; {
+		size, width := cols.next(line, column)
+
+		if line[0] == '\t' {
+			sb.WriteString(strings.Repeat(" ", width))
+		} else {
+			sb.WriteString(line[:size])
+		}
+
+		column += width
+		line = line[size:]
+	}
+
+	return sb.String()
+}
+
+// Returns the colour in which the text is rendered, or an empty string if colours are disabled.
+func (r Renderer) paint(s, colour string) string {
+	if !r.Colour || colour == "" || s == "" {
+		return s
+	}
+
+	return colour + s + ansiReset
+}
+
+// Returns the sorted numbers of the lines that are shown for the labels.
+func displayedLines(labels []placedLabel) []int {
+	var lines []int
+
+	for _, label := range labels {
+		lines = append(lines, label.startLine, label.endLine)
+
+		if label.endLine-label.startLine-1 <= maxContextLines {
+			for line := label.startLine + 1; line < label.endLine; line++ {
+				lines = append(lines, line)
+			}
+		}
+	}
+
+	slices.Sort(lines)
+
+	return slices.Compact(lines)
+}
+
+// Returns the colour in which the underline and message of the label are rendered.
+func markerColour(label Label) string {
+	if label.Primary {
+		return ansiRed
+	}
+
+	return ansiBlue
+}
+
+// A single character in the annotation area of a row, which consists of the margin followed by the code.
+type cell struct {
+	char   string
+	colour string
+}
+
+// Holds the state that's needed to render a single snippet.
+type layout struct {
+	renderer Renderer
+	labels   []placedLabel
+	gutter   int // The width of the line numbers.
+	margin   int // The width of the area that holds the bars of multi-line labels.
+	out      strings.Builder
+}
+
+// Writes the title of the snippet and the position it refers to.
+func (l *layout) writeHeader(input *Input, snippet Snippet) {
+	if snippet.Level != "" {
+		l.out.WriteString(l.renderer.paint(snippet.Level, levelColours[snippet.Level]) + ": ")
+	}
+
+	l.out.WriteString(l.renderer.paint(snippet.Title, ansiBold) + "\n")
+
+	if len(snippet.Labels) == 0 {
+		return
+	}
+
+	main := snippet.Labels[0]
+
+	if idx := slices.IndexFunc(snippet.Labels, func(label Label) bool { return label.Primary }); idx >= 0 {
+		main = snippet.Labels[idx]
+	}
+
+	pos := Position{
+		Filename: snippet.Name,
+		Offset:   main.Span.Start,
+		Location: input.LineCol(main.Span.Start),
+	}
+
+	l.out.WriteString(strings.Repeat(" ", l.gutter) + l.renderer.paint("-->", ansiBlue) + " " + pos.String() + "\n")
+	l.writeRow("", nil, "", "")
+}
+
+// Writes a single line of the input, followed by the underlines of the labels on that line.
+func (l *layout) writeLine(input *Input, line int) {
+	open := make(map[int]placedLabel)
+
+	for _, label := range l.labels {
+		if label.multiline() && label.startLine < line && line <= label.endLine {
+			open[label.depth] = label
+		}
+	}
+
+	cells := l.bars(open, l.margin)
+	code := l.renderer.expand(input.Line(line))
+
+	for len(cells) < l.margin && code != "" {
+		cells = append(cells, cell{char: " "})
+	}
+
+	cells = append(cells, cell{char: code})
+	l.writeRow(strconv.Itoa(line), cells, "", "")
+
+	// Open the multi-line labels that start on this line.
+	for _, label := range l.labels {
+		if label.multiline() && label.startLine == line {
+			cells := l.bars(open, l.margin)
+			l.fill(&cells, label.depth+1, l.margin+label.startCol-1, "_")
+			l.put(&cells, l.margin+label.startCol-1, label.marker(), markerColour(label.Label))
+			l.writeRow("", cells, "", "")
+
+			open[label.depth] = label
+		}
+	}
+
+	l.writeSingleLineLabels(open, line)
+
+	// Close the multi-line labels that end on this line.
+	for _, label := range l.labels {
+		if label.multiline() && label.endLine == line {
+			cells := l.bars(open, label.depth+1)
+			l.fill(&cells, label.depth+1, l.margin+label.endCol-1, "_")
+			l.put(&cells, l.margin+label.endCol-1, label.marker(), markerColour(label.Label))
+			l.writeRow("", cells, label.Message, markerColour(label.Label))
+
+			delete(open, label.depth)
+		}
+	}
+}
+
+// Writes the underlines of the labels that start and end on the given line.
+//
+// All underlines share a single row, which ends with the message of the rightmost label. The messages of the other
+// labels are hung below it, each connected to its underline with a vertical bar.
+func (l *layout) writeSingleLineLabels(open map[int]placedLabel, line int) {
+	var onLine []placedLabel
+
+	for _, label := range l.labels {
+		if !label.multiline() && label.startLine == line {
+			onLine = append(onLine, label)
+		}
+	}
+
+	if len(onLine) == 0 {
+		return
+	}
+
+	slices.SortStableFunc(onLine, func(a, b placedLabel) int {
+		return a.startCol - b.startCol
+	})
+
+	// Write the underlines, letting primary labels take precedence where labels overlap.
+	cells := l.bars(open, l.margin)
+
+	for _, primary := range []bool{false, true} {
+		for _, label := range onLine {
+			if label.Primary == primary {
+				for col := label.startCol; col < label.endCol; col++ {
+					l.put(&cells, l.margin+col-1, label.marker(), markerColour(label.Label))
+				}
+			}
+		}
+	}
+
+	last := onLine[len(onLine)-1]
+	l.writeRow("", cells, last.Message, markerColour(last.Label))
+
+	// Hang the messages of the other labels below the underlines, from right to left.
+	var hanging []placedLabel
+
+	for _, label := range onLine[:len(onLine)-1] {
+		if label.Message != "" {
+			hanging = append(hanging, label)
+		}
+	}
+
+	for idx := len(hanging) - 1; idx >= 0; idx-- {
+		cells := l.bars(open, l.margin)
+
+		for _, label := range hanging[:idx+1] {
+			l.put(&cells, l.margin+label.startCol-1, "|", markerColour(label.Label))
+		}
+
+		l.writeRow("", cells, "", "")
+
+		label := hanging[idx]
+		cells = cells[:l.margin+label.startCol-1]
+		l.put(&cells, len(cells), label.Message, markerColour(label.Label))
+		l.writeRow("", cells, "", "")
+	}
+}
+
+// Returns the cells of the margin, with a bar for every open multi-line label at a depth below limit.
+func (l *layout) bars(open map[int]placedLabel, limit int) []cell {
+	var cells []cell
+
+	for depth := 0; depth < limit; depth++ {
+		if label, ok := open[depth]; ok {
+			l.put(&cells, depth, "|", markerColour(label.Label))
+		}
+	}
+
+	return cells
+}
+
+// Sets the cell at the given 0-based index, growing the row as needed.
+func (l *layout) put(cells *[]cell, idx int, char, colour string) {
+	for len(*cells) <= idx {
+		*cells = append(*cells, cell{char: " "})
+	}
+
+	(*cells)[idx] = cell{char: char, colour: colour}
+}
+
+// Draws a horizontal line over the empty cells in the range [from, to).
+func (l *layout) fill(cells *[]cell, from, to int, char string) {
+	for idx := from; idx < to; idx++ {
+		if idx >= len(*cells) || (*cells)[idx].char == " " {
+			l.put(cells, idx, char, ansiBlue)
+		}
+	}
+}
+
+// Writes a row with the given text in the gutter, followed by the cells and an optional message.
+func (l *layout) writeRow(gutter string, cells []cell, message, colour string) {
+	l.out.WriteString(l.renderer.paint(gutter, ansiBlue))
+
+	// The ellipsis that marks skipped lines can be wider than the line numbers, and has nothing after it.
+	if gutter == "..." {
+		l.out.WriteString("\n")
+
+		return
+	}
+
+	l.out.WriteString(strings.Repeat(" ", l.gutter-len(gutter)+1))
+
+	l.out.WriteString(l.renderer.paint("|", ansiBlue))
+
+	for len(cells) > 0 && cells[len(cells)-1].char == " " {
+		cells = cells[:len(cells)-1]
+	}
+
+	if len(cells) > 0 || message != "" {
+		l.out.WriteString(" ")
+	}
+
+	for _, cell := range cells {
+		l.out.WriteString(l.renderer.paint(cell.char, cell.colour))
+	}
+
+	if message != "" {
+		if len(cells) > 0 {
+			l.out.WriteString(" ")
+		}
+
+		l.out.WriteString(l.renderer.paint(message, colour))
+	}
+
+	l.out.WriteString("\n")
+}
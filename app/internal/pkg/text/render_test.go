@@ -0,0 +1,243 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify the implementation of the "text" package.
+package text_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kdeconinck/lens/internal/pkg/assert"
+	"github.com/kdeconinck/lens/internal/pkg/text"
+)
+
+// UT: Verify that snippets are rendered as an excerpt of the source with labelled underlines.
+func TestRenderer_Render(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	const content = "version = 1.0\nextension: \".cs {\n\tfoo: bar\n    baz\n}\n"
+
+	for tcName, tc := range map[string]struct {
+		snippetInput text.Snippet
+		want         string
+	}{
+		"When the snippet has a single primary label, it's underlined with '^'.": {
+			snippetInput: text.Snippet{
+				Level:  "error",
+				Title:  "Unclosed string literal.",
+				Name:   "rules.lux",
+				Labels: []text.Label{{Span: newSpan(25, 30), Message: "The string starts here.", Primary: true}},
+			},
+			want: "" +
+				"error: Unclosed string literal.\n" +
+				" --> rules.lux:2:12\n" +
+				"  |\n" +
+				"2 | extension: \".cs {\n" +
+				"  |            ^^^^^ The string starts here.\n",
+		},
+		"When the snippet has labels on different lines, every line is shown with its own underline.": {
+			snippetInput: text.Snippet{
+				Level: "error",
+				Title: "Duplicate key.",
+				Labels: []text.Label{
+					{Span: newSpan(0, 7), Message: "First defined here."},
+					{Span: newSpan(14, 23), Message: "Defined again here.", Primary: true},
+				},
+			},
+			want: "" +
+				"error: Duplicate key.\n" +
+				" --> 2:1\n" +
+				"  |\n" +
+				"1 | version = 1.0\n" +
+				"  | ------- First defined here.\n" +
+				"2 | extension: \".cs {\n" +
+				"  | ^^^^^^^^^ Defined again here.\n",
+		},
+		"When the snippet has multiple labels on a line, the messages are hung below the underlines.": {
+			snippetInput: text.Snippet{
+				Title: "Invalid value.",
+				Labels: []text.Label{
+					{Span: newSpan(14, 23), Message: "For this key."},
+					{Span: newSpan(25, 30), Message: "This value.", Primary: true},
+				},
+			},
+			want: "" +
+				"Invalid value.\n" +
+				" --> 2:12\n" +
+				"  |\n" +
+				"2 | extension: \".cs {\n" +
+				"  | ---------  ^^^^^ This value.\n" +
+				"  | |\n" +
+				"  | For this key.\n",
+		},
+		"When a label points at a line with a tab, the tab is expanded so that the underline lines up.": {
+			snippetInput: text.Snippet{
+				Level:  "warning",
+				Title:  "Unknown key.",
+				Labels: []text.Label{{Span: newSpan(33, 36), Primary: true}},
+			},
+			want: "" +
+				"warning: Unknown key.\n" +
+				" --> 3:2\n" +
+				"  |\n" +
+				"3 |     foo: bar\n" +
+				"  |     ^^^\n",
+		},
+		"When a label is empty, a single caret is shown.": {
+			snippetInput: text.Snippet{
+				Level:  "error",
+				Title:  "Expected '{'.",
+				Labels: []text.Label{{Span: newSpan(13, 13), Message: "Here.", Primary: true}},
+			},
+			want: "" +
+				"error: Expected '{'.\n" +
+				" --> 1:14\n" +
+				"  |\n" +
+				"1 | version = 1.0\n" +
+				"  |              ^ Here.\n",
+		},
+		"When the labelled lines aren't adjacent, the lines in between are replaced with '...'.": {
+			snippetInput: text.Snippet{
+				Level: "error",
+				Title: "Unbalanced braces.",
+				Labels: []text.Label{
+					{Span: newSpan(0, 7), Message: "In this rule."},
+					{Span: newSpan(50, 51), Message: "This brace.", Primary: true},
+				},
+			},
+			want: "" +
+				"error: Unbalanced braces.\n" +
+				" --> 5:1\n" +
+				"  |\n" +
+				"1 | version = 1.0\n" +
+				"  | ------- In this rule.\n" +
+				"...\n" +
+				"5 | }\n" +
+				"  | ^ This brace.\n",
+		},
+		"When a label spans multiple lines, its start and end are connected in the margin.": {
+			snippetInput: text.Snippet{
+				Level: "error",
+				Title: "Unclosed block.",
+				Labels: []text.Label{
+					{Span: newSpan(31, 51), Message: "This block.", Primary: true},
+					{Span: newSpan(33, 36), Message: "Inner key."},
+				},
+			},
+			want: "" +
+				"error: Unclosed block.\n" +
+				" --> 2:18\n" +
+				"  |\n" +
+				"2 |   extension: \".cs {\n" +
+				"  |  __________________^\n" +
+				"3 | |     foo: bar\n" +
+				"  | |     --- Inner key.\n" +
+				"4 | |     baz\n" +
+				"5 | | }\n" +
+				"  | |_^ This block.\n",
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			input := newInput(content)
+			renderer := text.Renderer{}
+
+			var sb strings.Builder
+
+			// Act.
+			err := renderer.Render(&sb, &input, tc.snippetInput)
+			got := sb.String()
+
+			// Assert.
+			assert.Equalf(t, err, nil, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: <nil>\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, err)
+
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected:\n%s\033[0m\n"+
+				"\033[31mActual:\n%s\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Verify that snippets can be rendered with ANSI colours.
+func TestRenderer_Render_Colour(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	input := newInput("@")
+	renderer := text.Renderer{Colour: true}
+	snippet := text.Snippet{
+		Level:  "error",
+		Title:  "Invalid character.",
+		Labels: []text.Label{{Span: newSpan(0, 1), Primary: true}},
+	}
+
+	var sb strings.Builder
+
+	// Act.
+	_ = renderer.Render(&sb, &input, snippet)
+	got := sb.String()
+	want := "" +
+		"\033[1;31merror\033[0m: \033[1mInvalid character.\033[0m\n" +
+		" \033[1;34m-->\033[0m 1:1\n" +
+		"  \033[1;34m|\033[0m\n" +
+		"\033[1;34m1\033[0m \033[1;34m|\033[0m @\n" +
+		"  \033[1;34m|\033[0m \033[1;31m^\033[0m\n"
+
+	// Assert.
+	assert.Equalf(t, got, want, "\n\n"+
+		"UT Name:  When rendering with colours, ANSI escape sequences are used.\n"+
+		"\033[32mExpected: %q\033[0m\n"+
+		"\033[31mActual:   %q\033[0m\n\n", want, got)
+}
+
+// UT: Verify that labels that don't fit in the input are rejected.
+func TestRenderer_Render_Invalid(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	input := newInput("abc")
+	snippet := text.Snippet{
+		Labels: []text.Label{{Span: newSpan(2, 9), Primary: true}},
+	}
+
+	var sb strings.Builder
+
+	// Act.
+	err := text.Renderer{}.Render(&sb, &input, snippet)
+
+	// Assert.
+	assert.Equalf(t, errors.Is(err, text.ErrSpanOutOfRange), true, "\n\n"+
+		"UT Name:  When a label doesn't fit in the input, an error is returned.\n"+
+		"\033[32mExpected: %v\033[0m\n"+
+		"\033[31mActual:   %v\033[0m\n\n", text.ErrSpanOutOfRange, err)
+}
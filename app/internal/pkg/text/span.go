@@ -29,7 +29,13 @@
 // human-readable dimensions like line and columns.
 package text
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrSpanOutOfRange is reported when a [Span] reaches outside of an [Input].
+var ErrSpanOutOfRange = errors.New("span out of range")
 
 // Span represents a discrete region within a body of text.
 //
@@ -39,7 +45,7 @@ type Span struct {
 	// Start is the 0-based byte offset of the first byte in the span.
 	Start int
 
-	// End if the 0-based byte offset of the first byte following the span.
+	// End is the 0-based byte offset of the first byte following the span.
 	End int
 }
 
@@ -47,3 +53,134 @@ type Span struct {
 func (span Span) String() string {
 	return fmt.Sprintf("%d..%d", span.Start, span.End)
 }
+
+// Len returns the number of bytes in the span.
+func (span Span) Len() int {
+	return span.End - span.Start
+}
+
+// IsEmpty reports whether the span doesn't contain any byte.
+func (span Span) IsEmpty() bool {
+	return span.End <= span.Start
+}
+
+// Contains reports whether the byte at offset is part of the span.
+func (span Span) Contains(offset int) bool {
+	return span.Start <= offset && offset < span.End
+}
+
+// ContainsSpan reports whether other lies completely within the span.
+//
+// An empty span is contained in every span that starts at or before it and ends at or after it.
+func (span Span) ContainsSpan(other Span) bool {
+	return span.Start <= other.Start && other.End <= span.End
+}
+
+// Overlaps reports whether the span and other have at least one byte in common.
+//
+// Spans that merely touch, and empty spans, never overlap.
+func (span Span) Overlaps(other Span) bool {
+	if span.IsEmpty() || other.IsEmpty() {
+		return false
+	}
+
+	return span.Start < other.End && other.Start < span.End
+}
+
+// Intersect returns the region that the span and other have in common.
+//
+// Spans that touch intersect in an empty span at the point where they meet. If the spans are disjoint, the zero Span
+// and false are returned.
+func (span Span) Intersect(other Span) (Span, bool) {
+	result := Span{
+		Start: max(span.Start, other.Start),
+		End:   min(span.End, other.End),
+	}
+
+	if result.Start > result.End {
+		return Span{}, false
+	}
+
+	return result, true
+}
+
+// Union returns the smallest span that covers both the span and other, including any gap between them.
+func (span Span) Union(other Span) Span {
+	return Span{
+		Start: min(span.Start, other.Start),
+		End:   max(span.End, other.End),
+	}
+}
+
+// Slice returns the content of input that the span covers.
+//
+// Slice panics if the span isn't valid for input; see [Span.Validate].
+func (span Span) Slice(input *Input) string {
+	return input.Content[span.Start:span.End]
+}
+
+// Validate reports whether the span can be used to address input.
+//
+// An error wrapping [ErrInvertedSpan] is returned if the span ends before it starts, and an error wrapping
+// [ErrSpanOutOfRange] is returned if it reaches outside of input.
+func (span Span) Validate(input *Input) error {
+	return span.validate(len(input.Content))
+}
+
+// Shift returns the span as it's positioned after the bytes covered by edit are replaced with n new bytes.
+//
+// Insertions are modelled with an empty edit and deletions with n set to 0. Text inserted at the start of the span
+// pushes it forward, while text inserted at its end isn't included. When the edit replaces part of the span, the span
+// shrinks to the bytes that survived the edit. When the edit replaces exactly the span, the result covers the new
+// bytes, and when it reaches beyond the span on both sides, the result is an empty span directly after the new bytes.
+func (span Span) Shift(edit Span, n int) Span {
+	result := Span{
+		Start: shiftStart(span.Start, edit, n),
+		End:   shiftEnd(span.End, edit, n),
+	}
+
+	if result.End < result.Start {
+		result.End = result.Start
+	}
+
+	return result
+}
+
+// Returns the position of the first byte of a span after the bytes covered by edit are replaced with n new bytes.
+func shiftStart(offset int, edit Span, n int) int {
+	switch {
+	case offset >= edit.End:
+		return offset + n - edit.Len()
+
+	case offset > edit.Start:
+		return edit.Start + n
+	}
+
+	return offset
+}
+
+// Returns the position of the byte following a span after the bytes covered by edit are replaced with n new bytes.
+func shiftEnd(offset int, edit Span, n int) int {
+	switch {
+	case offset <= edit.Start:
+		return offset
+
+	case offset < edit.End:
+		return edit.Start
+	}
+
+	return offset + n - edit.Len()
+}
+
+// Returns an error if the span is inverted or doesn't fit in a text of n bytes.
+func (span Span) validate(n int) error {
+	if span.End < span.Start {
+		return fmt.Errorf("%w: %s", ErrInvertedSpan, span)
+	}
+
+	if span.Start < 0 || span.End > n {
+		return fmt.Errorf("%w: %s (the input has %d bytes)", ErrSpanOutOfRange, span, n)
+	}
+
+	return nil
+}
@@ -27,6 +27,7 @@
 package text_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/kdeconinck/lens/internal/pkg/assert"
@@ -50,6 +51,315 @@ func Test_SpanString(t *testing.T) {
 		"\033[31mActual:   %s\033[0m\n\n", want, got)
 }
 
+// UT: Verify the length of a span.
+func TestSpan_Len(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		spanInput text.Span
+		want      int
+		wantEmpty bool
+	}{
+		"When the span is empty, its length is 0.": {
+			spanInput: newSpan(4, 4),
+			want:      0,
+			wantEmpty: true,
+		},
+		"When the span covers bytes, its length is the number of bytes.": {
+			spanInput: newSpan(4, 9),
+			want:      5,
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got, gotEmpty := tc.spanInput.Len(), tc.spanInput.IsEmpty()
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d\033[0m\n"+
+				"\033[31mActual:   %d\033[0m\n\n", tcName, tc.want, got)
+
+			assert.Equalf(t, gotEmpty, tc.wantEmpty, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: IsEmpty() = %t\033[0m\n"+
+				"\033[31mActual:   IsEmpty() = %t\033[0m\n\n", tcName, tc.wantEmpty, gotEmpty)
+		})
+	}
+}
+
+// UT: Verify whether an offset is part of a span.
+func TestSpan_Contains(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		offsetInput int
+		want        bool
+	}{
+		"When the offset is before the span, it isn't contained.": {
+			offsetInput: 1,
+			want:        false,
+		},
+		"When the offset is the start of the span, it's contained.": {
+			offsetInput: 2,
+			want:        true,
+		},
+		"When the offset is the last byte of the span, it's contained.": {
+			offsetInput: 4,
+			want:        true,
+		},
+		"When the offset is the end of the span, it isn't contained.": {
+			offsetInput: 5,
+			want:        false,
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := newSpan(2, 5).Contains(tc.offsetInput)
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %t\033[0m\n"+
+				"\033[31mActual:   %t\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Verify the relations between 2 spans.
+func TestSpan_Relations(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		otherInput       text.Span
+		wantContainsSpan bool
+		wantOverlaps     bool
+		wantIntersect    text.Span
+		wantIntersects   bool
+		wantUnion        text.Span
+	}{
+		"When the other span is inside the span, it's contained and overlaps.": {
+			otherInput:       newSpan(12, 15),
+			wantContainsSpan: true,
+			wantOverlaps:     true,
+			wantIntersect:    newSpan(12, 15),
+			wantIntersects:   true,
+			wantUnion:        newSpan(10, 20),
+		},
+		"When the other span crosses the start of the span, it overlaps but isn't contained.": {
+			otherInput:       newSpan(5, 12),
+			wantContainsSpan: false,
+			wantOverlaps:     true,
+			wantIntersect:    newSpan(10, 12),
+			wantIntersects:   true,
+			wantUnion:        newSpan(5, 20),
+		},
+		"When the other span touches the end of the span, it neither overlaps nor is contained.": {
+			otherInput:       newSpan(20, 25),
+			wantContainsSpan: false,
+			wantOverlaps:     false,
+			wantIntersect:    newSpan(20, 20),
+			wantIntersects:   true,
+			wantUnion:        newSpan(10, 25),
+		},
+		"When the other span is disjoint, it doesn't intersect and the union covers the gap.": {
+			otherInput:       newSpan(30, 35),
+			wantContainsSpan: false,
+			wantOverlaps:     false,
+			wantIntersect:    newSpan(0, 0),
+			wantIntersects:   false,
+			wantUnion:        newSpan(10, 35),
+		},
+		"When the other span is empty and inside the span, it's contained but doesn't overlap.": {
+			otherInput:       newSpan(15, 15),
+			wantContainsSpan: true,
+			wantOverlaps:     false,
+			wantIntersect:    newSpan(15, 15),
+			wantIntersects:   true,
+			wantUnion:        newSpan(10, 20),
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			span := newSpan(10, 20)
+
+			// Act.
+			gotContainsSpan, gotOverlaps := span.ContainsSpan(tc.otherInput), span.Overlaps(tc.otherInput)
+			gotIntersect, gotIntersects := span.Intersect(tc.otherInput)
+			gotUnion := span.Union(tc.otherInput)
+
+			// Assert.
+			assert.Equalf(t, gotContainsSpan, tc.wantContainsSpan, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: ContainsSpan() = %t\033[0m\n"+
+				"\033[31mActual:   ContainsSpan() = %t\033[0m\n\n", tcName, tc.wantContainsSpan, gotContainsSpan)
+
+			assert.Equalf(t, gotOverlaps, tc.wantOverlaps, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: Overlaps() = %t\033[0m\n"+
+				"\033[31mActual:   Overlaps() = %t\033[0m\n\n", tcName, tc.wantOverlaps, gotOverlaps)
+
+			assert.Equalf(t, gotIntersects, tc.wantIntersects, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: Intersect() = %t\033[0m\n"+
+				"\033[31mActual:   Intersect() = %t\033[0m\n\n", tcName, tc.wantIntersects, gotIntersects)
+
+			assert.Equalf(t, gotIntersect, tc.wantIntersect, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: Intersect() = %s\033[0m\n"+
+				"\033[31mActual:   Intersect() = %s\033[0m\n\n", tcName, tc.wantIntersect, gotIntersect)
+
+			assert.Equalf(t, gotUnion, tc.wantUnion, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: Union() = %s\033[0m\n"+
+				"\033[31mActual:   Union() = %s\033[0m\n\n", tcName, tc.wantUnion, gotUnion)
+		})
+	}
+}
+
+// UT: Verify that a span can be used to slice an input.
+func TestSpan_Slice(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	input := newInput("Hello, World")
+
+	// Act.
+	got, want := newSpan(7, 12).Slice(&input), "World"
+
+	// Assert.
+	assert.Equalf(t, got, want, "\n\n"+
+		"UT Name:  When slicing an input, the content covered by the span is returned.\n"+
+		"\033[32mExpected: %s\033[0m\n"+
+		"\033[31mActual:   %s\033[0m\n\n", want, got)
+}
+
+// UT: Verify that a span is validated against an input.
+func TestSpan_Validate(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		spanInput text.Span
+		want      error
+	}{
+		"When the span is inside the input, it's valid.": {
+			spanInput: newSpan(0, 5),
+		},
+		"When the span is empty at the end of the input, it's valid.": {
+			spanInput: newSpan(5, 5),
+		},
+		"When the span ends before it starts, it's invalid.": {
+			spanInput: newSpan(3, 2),
+			want:      text.ErrInvertedSpan,
+		},
+		"When the span starts before the input, it's invalid.": {
+			spanInput: newSpan(-1, 2),
+			want:      text.ErrSpanOutOfRange,
+		},
+		"When the span ends after the input, it's invalid.": {
+			spanInput: newSpan(3, 6),
+			want:      text.ErrSpanOutOfRange,
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			input := newInput("Hello")
+
+			// Act.
+			got := tc.spanInput.Validate(&input)
+
+			// Assert.
+			assert.Equalf(t, errors.Is(got, tc.want), true, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Verify that a span moves through an edit.
+func TestSpan_Shift(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		editInput text.Span
+		nInput    int
+		want      text.Span
+	}{
+		"When text is inserted after the span, the span doesn't move.": {
+			editInput: newSpan(25, 25),
+			nInput:    3,
+			want:      newSpan(10, 20),
+		},
+		"When text is inserted at the end of the span, the span doesn't grow.": {
+			editInput: newSpan(20, 20),
+			nInput:    3,
+			want:      newSpan(10, 20),
+		},
+		"When text is inserted at the start of the span, the span moves forward.": {
+			editInput: newSpan(10, 10),
+			nInput:    3,
+			want:      newSpan(13, 23),
+		},
+		"When text is inserted inside the span, the span grows.": {
+			editInput: newSpan(15, 15),
+			nInput:    3,
+			want:      newSpan(10, 23),
+		},
+		"When text is deleted before the span, the span moves backward.": {
+			editInput: newSpan(2, 5),
+			nInput:    0,
+			want:      newSpan(7, 17),
+		},
+		"When text is deleted inside the span, the span shrinks.": {
+			editInput: newSpan(12, 15),
+			nInput:    0,
+			want:      newSpan(10, 17),
+		},
+		"When text that crosses the start of the span is replaced, the span starts after the new text.": {
+			editInput: newSpan(5, 15),
+			nInput:    2,
+			want:      newSpan(7, 12),
+		},
+		"When text that crosses the end of the span is replaced, the span ends before the new text.": {
+			editInput: newSpan(15, 25),
+			nInput:    2,
+			want:      newSpan(10, 15),
+		},
+		"When exactly the span is replaced, the span covers the new text.": {
+			editInput: newSpan(10, 20),
+			nInput:    2,
+			want:      newSpan(10, 12),
+		},
+		"When text around the span is replaced, the span becomes empty after the new text.": {
+			editInput: newSpan(5, 25),
+			nInput:    2,
+			want:      newSpan(7, 7),
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := newSpan(10, 20).Shift(tc.editInput, tc.nInput)
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
 // Returns a new span with the given start and end positions.
 func newSpan(start, end int) text.Span {
 	return text.Span{
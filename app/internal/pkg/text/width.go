@@ -0,0 +1,392 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package text provides foundational types for managing and measuring text content.
+//
+// It serves as a coordinate system for UTF-8 encoded strings, defining how raw byte segments [Span]s map to
+// human-readable dimensions like line and columns.
+package text
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// The grapheme cluster break properties (UAX #29) that are relevant for segmenting source code.
+const (
+	gbOther = iota
+	gbCR
+	gbLF
+	gbControl
+	gbExtend
+	gbZWJ
+	gbRegionalIndicator
+	gbSpacingMark
+	gbL
+	gbV
+	gbT
+	gbLV
+	gbLVT
+	gbPictographic
+)
+
+// The characters with an East Asian Width of Wide (W) or Fullwidth (F).
+var eastAsianWide = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x115F, Stride: 1},
+		{Lo: 0x231A, Hi: 0x231B, Stride: 1},
+		{Lo: 0x2329, Hi: 0x232A, Stride: 1},
+		{Lo: 0x23E9, Hi: 0x23EC, Stride: 1},
+		{Lo: 0x23F0, Hi: 0x23F0, Stride: 1},
+		{Lo: 0x23F3, Hi: 0x23F3, Stride: 1},
+		{Lo: 0x25FD, Hi: 0x25FE, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2615, Stride: 1},
+		{Lo: 0x2648, Hi: 0x2653, Stride: 1},
+		{Lo: 0x267F, Hi: 0x267F, Stride: 1},
+		{Lo: 0x2693, Hi: 0x2693, Stride: 1},
+		{Lo: 0x26A1, Hi: 0x26A1, Stride: 1},
+		{Lo: 0x26AA, Hi: 0x26AB, Stride: 1},
+		{Lo: 0x26BD, Hi: 0x26BE, Stride: 1},
+		{Lo: 0x26C4, Hi: 0x26C5, Stride: 1},
+		{Lo: 0x26CE, Hi: 0x26CE, Stride: 1},
+		{Lo: 0x26D4, Hi: 0x26D4, Stride: 1},
+		{Lo: 0x26EA, Hi: 0x26EA, Stride: 1},
+		{Lo: 0x26F2, Hi: 0x26F3, Stride: 1},
+		{Lo: 0x26F5, Hi: 0x26F5, Stride: 1},
+		{Lo: 0x26FA, Hi: 0x26FA, Stride: 1},
+		{Lo: 0x26FD, Hi: 0x26FD, Stride: 1},
+		{Lo: 0x2705, Hi: 0x2705, Stride: 1},
+		{Lo: 0x270A, Hi: 0x270B, Stride: 1},
+		{Lo: 0x2728, Hi: 0x2728, Stride: 1},
+		{Lo: 0x274C, Hi: 0x274C, Stride: 1},
+		{Lo: 0x274E, Hi: 0x274E, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27B0, Hi: 0x27B0, Stride: 1},
+		{Lo: 0x27BF, Hi: 0x27BF, Stride: 1},
+		{Lo: 0x2B1B, Hi: 0x2B1C, Stride: 1},
+		{Lo: 0x2B50, Hi: 0x2B50, Stride: 1},
+		{Lo: 0x2B55, Hi: 0x2B55, Stride: 1},
+		{Lo: 0x2E80, Hi: 0x303E, Stride: 1},
+		{Lo: 0x3041, Hi: 0x33FF, Stride: 1},
+		{Lo: 0x3400, Hi: 0x4DBF, Stride: 1},
+		{Lo: 0x4E00, Hi: 0x9FFF, Stride: 1},
+		{Lo: 0xA000, Hi: 0xA4CF, Stride: 1},
+		{Lo: 0xA960, Hi: 0xA97F, Stride: 1},
+		{Lo: 0xAC00, Hi: 0xD7A3, Stride: 1},
+		{Lo: 0xF900, Hi: 0xFAFF, Stride: 1},
+		{Lo: 0xFE10, Hi: 0xFE19, Stride: 1},
+		{Lo: 0xFE30, Hi: 0xFE6F, Stride: 1},
+		{Lo: 0xFF00, Hi: 0xFF60, Stride: 1},
+		{Lo: 0xFFE0, Hi: 0xFFE6, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x16FE0, Hi: 0x16FE4, Stride: 1},
+		{Lo: 0x17000, Hi: 0x18AFF, Stride: 1},
+		{Lo: 0x1B000, Hi: 0x1B2FF, Stride: 1},
+		{Lo: 0x1F004, Hi: 0x1F004, Stride: 1},
+		{Lo: 0x1F0CF, Hi: 0x1F0CF, Stride: 1},
+		{Lo: 0x1F18E, Hi: 0x1F18E, Stride: 1},
+		{Lo: 0x1F191, Hi: 0x1F19A, Stride: 1},
+		{Lo: 0x1F200, Hi: 0x1F202, Stride: 1},
+		{Lo: 0x1F210, Hi: 0x1F23B, Stride: 1},
+		{Lo: 0x1F240, Hi: 0x1F248, Stride: 1},
+		{Lo: 0x1F250, Hi: 0x1F251, Stride: 1},
+		{Lo: 0x1F260, Hi: 0x1F265, Stride: 1},
+		{Lo: 0x1F300, Hi: 0x1F320, Stride: 1},
+		{Lo: 0x1F32D, Hi: 0x1F335, Stride: 1},
+		{Lo: 0x1F337, Hi: 0x1F37C, Stride: 1},
+		{Lo: 0x1F37E, Hi: 0x1F393, Stride: 1},
+		{Lo: 0x1F3A0, Hi: 0x1F3CA, Stride: 1},
+		{Lo: 0x1F3CF, Hi: 0x1F3D3, Stride: 1},
+		{Lo: 0x1F3E0, Hi: 0x1F3F0, Stride: 1},
+		{Lo: 0x1F3F4, Hi: 0x1F3F4, Stride: 1},
+		{Lo: 0x1F3F8, Hi: 0x1F43E, Stride: 1},
+		{Lo: 0x1F440, Hi: 0x1F440, Stride: 1},
+		{Lo: 0x1F442, Hi: 0x1F4FC, Stride: 1},
+		{Lo: 0x1F4FF, Hi: 0x1F53D, Stride: 1},
+		{Lo: 0x1F54B, Hi: 0x1F54E, Stride: 1},
+		{Lo: 0x1F550, Hi: 0x1F567, Stride: 1},
+		{Lo: 0x1F57A, Hi: 0x1F57A, Stride: 1},
+		{Lo: 0x1F595, Hi: 0x1F596, Stride: 1},
+		{Lo: 0x1F5A4, Hi: 0x1F5A4, Stride: 1},
+		{Lo: 0x1F5FB, Hi: 0x1F64F, Stride: 1},
+		{Lo: 0x1F680, Hi: 0x1F6C5, Stride: 1},
+		{Lo: 0x1F6CC, Hi: 0x1F6CC, Stride: 1},
+		{Lo: 0x1F6D0, Hi: 0x1F6D2, Stride: 1},
+		{Lo: 0x1F6D5, Hi: 0x1F6D7, Stride: 1},
+		{Lo: 0x1F6DC, Hi: 0x1F6DF, Stride: 1},
+		{Lo: 0x1F6EB, Hi: 0x1F6EC, Stride: 1},
+		{Lo: 0x1F6F4, Hi: 0x1F6FC, Stride: 1},
+		{Lo: 0x1F7E0, Hi: 0x1F7EB, Stride: 1},
+		{Lo: 0x1F7F0, Hi: 0x1F7F0, Stride: 1},
+		{Lo: 0x1F90C, Hi: 0x1F93A, Stride: 1},
+		{Lo: 0x1F93C, Hi: 0x1F945, Stride: 1},
+		{Lo: 0x1F947, Hi: 0x1F9FF, Stride: 1},
+		{Lo: 0x1FA70, Hi: 0x1FAFF, Stride: 1},
+		{Lo: 0x20000, Hi: 0x2FFFD, Stride: 1},
+		{Lo: 0x30000, Hi: 0x3FFFD, Stride: 1},
+	},
+}
+
+// The characters with the Extended_Pictographic property, which can be joined into emoji sequences.
+var extendedPictographic = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x00A9, Hi: 0x00A9, Stride: 1},
+		{Lo: 0x00AE, Hi: 0x00AE, Stride: 1},
+		{Lo: 0x203C, Hi: 0x203C, Stride: 1},
+		{Lo: 0x2049, Hi: 0x2049, Stride: 1},
+		{Lo: 0x2122, Hi: 0x2122, Stride: 1},
+		{Lo: 0x2139, Hi: 0x2139, Stride: 1},
+		{Lo: 0x2194, Hi: 0x2199, Stride: 1},
+		{Lo: 0x21A9, Hi: 0x21AA, Stride: 1},
+		{Lo: 0x231A, Hi: 0x231B, Stride: 1},
+		{Lo: 0x2328, Hi: 0x2328, Stride: 1},
+		{Lo: 0x2388, Hi: 0x2388, Stride: 1},
+		{Lo: 0x23CF, Hi: 0x23CF, Stride: 1},
+		{Lo: 0x23E9, Hi: 0x23F3, Stride: 1},
+		{Lo: 0x23F8, Hi: 0x23FA, Stride: 1},
+		{Lo: 0x24C2, Hi: 0x24C2, Stride: 1},
+		{Lo: 0x25AA, Hi: 0x25AB, Stride: 1},
+		{Lo: 0x25B6, Hi: 0x25B6, Stride: 1},
+		{Lo: 0x25C0, Hi: 0x25C0, Stride: 1},
+		{Lo: 0x25FB, Hi: 0x25FE, Stride: 1},
+		{Lo: 0x2600, Hi: 0x2605, Stride: 1},
+		{Lo: 0x2607, Hi: 0x2612, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2685, Stride: 1},
+		{Lo: 0x2690, Hi: 0x2705, Stride: 1},
+		{Lo: 0x2708, Hi: 0x2712, Stride: 1},
+		{Lo: 0x2714, Hi: 0x2714, Stride: 1},
+		{Lo: 0x2716, Hi: 0x2716, Stride: 1},
+		{Lo: 0x271D, Hi: 0x271D, Stride: 1},
+		{Lo: 0x2721, Hi: 0x2721, Stride: 1},
+		{Lo: 0x2728, Hi: 0x2728, Stride: 1},
+		{Lo: 0x2733, Hi: 0x2734, Stride: 1},
+		{Lo: 0x2744, Hi: 0x2744, Stride: 1},
+		{Lo: 0x2747, Hi: 0x2747, Stride: 1},
+		{Lo: 0x274C, Hi: 0x274C, Stride: 1},
+		{Lo: 0x274E, Hi: 0x274E, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2763, Hi: 0x2767, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27A1, Hi: 0x27A1, Stride: 1},
+		{Lo: 0x27B0, Hi: 0x27B0, Stride: 1},
+		{Lo: 0x27BF, Hi: 0x27BF, Stride: 1},
+		{Lo: 0x2934, Hi: 0x2935, Stride: 1},
+		{Lo: 0x2B05, Hi: 0x2B07, Stride: 1},
+		{Lo: 0x2B1B, Hi: 0x2B1C, Stride: 1},
+		{Lo: 0x2B50, Hi: 0x2B50, Stride: 1},
+		{Lo: 0x2B55, Hi: 0x2B55, Stride: 1},
+		{Lo: 0x3030, Hi: 0x3030, Stride: 1},
+		{Lo: 0x303D, Hi: 0x303D, Stride: 1},
+		{Lo: 0x3297, Hi: 0x3297, Stride: 1},
+		{Lo: 0x3299, Hi: 0x3299, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1F000, Hi: 0x1F0FF, Stride: 1},
+		{Lo: 0x1F10D, Hi: 0x1F10F, Stride: 1},
+		{Lo: 0x1F12F, Hi: 0x1F12F, Stride: 1},
+		{Lo: 0x1F16C, Hi: 0x1F171, Stride: 1},
+		{Lo: 0x1F17E, Hi: 0x1F17F, Stride: 1},
+		{Lo: 0x1F18E, Hi: 0x1F18E, Stride: 1},
+		{Lo: 0x1F191, Hi: 0x1F19A, Stride: 1},
+		{Lo: 0x1F1AD, Hi: 0x1F1E5, Stride: 1},
+		{Lo: 0x1F201, Hi: 0x1F20F, Stride: 1},
+		{Lo: 0x1F21A, Hi: 0x1F21A, Stride: 1},
+		{Lo: 0x1F22F, Hi: 0x1F22F, Stride: 1},
+		{Lo: 0x1F232, Hi: 0x1F23A, Stride: 1},
+		{Lo: 0x1F23C, Hi: 0x1F23F, Stride: 1},
+		{Lo: 0x1F249, Hi: 0x1F3FA, Stride: 1},
+		{Lo: 0x1F400, Hi: 0x1F53D, Stride: 1},
+		{Lo: 0x1F546, Hi: 0x1F64F, Stride: 1},
+		{Lo: 0x1F680, Hi: 0x1F6FF, Stride: 1},
+		{Lo: 0x1F774, Hi: 0x1F77F, Stride: 1},
+		{Lo: 0x1F7D5, Hi: 0x1F7FF, Stride: 1},
+		{Lo: 0x1F80C, Hi: 0x1F80F, Stride: 1},
+		{Lo: 0x1F848, Hi: 0x1F84F, Stride: 1},
+		{Lo: 0x1F85A, Hi: 0x1F85F, Stride: 1},
+		{Lo: 0x1F888, Hi: 0x1F88F, Stride: 1},
+		{Lo: 0x1F8AE, Hi: 0x1F8FF, Stride: 1},
+		{Lo: 0x1F90C, Hi: 0x1F93A, Stride: 1},
+		{Lo: 0x1F93C, Hi: 0x1F945, Stride: 1},
+		{Lo: 0x1F947, Hi: 0x1FAFF, Stride: 1},
+		{Lo: 0x1FC00, Hi: 0x1FFFD, Stride: 1},
+	},
+}
+
+// Returns the grapheme cluster break property of r.
+func graphemeBreak(r rune) int {
+	switch {
+	case r == '\r':
+		return gbCR
+
+	case r == '\n':
+		return gbLF
+
+	case r == 0x200D:
+		return gbZWJ
+
+	case r == 0x200C, r >= 0x1F3FB && r <= 0x1F3FF, r >= 0xE0020 && r <= 0xE007F, r == 0xFF9E, r == 0xFF9F:
+		return gbExtend
+
+	case r >= 0x1F1E6 && r <= 0x1F1FF:
+		return gbRegionalIndicator
+
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return gbL
+
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return gbV
+
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return gbT
+
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return gbLV
+		}
+
+		return gbLVT
+
+	case unicode.In(r, unicode.Mn, unicode.Me):
+		return gbExtend
+
+	case unicode.Is(unicode.Mc, r):
+		return gbSpacingMark
+
+	case unicode.In(r, unicode.Cc, unicode.Cf, unicode.Zl, unicode.Zp):
+		return gbControl
+
+	case unicode.Is(extendedPictographic, r):
+		return gbPictographic
+	}
+
+	return gbOther
+}
+
+// Returns the length in bytes of the extended grapheme cluster at the start of s.
+//
+// The segmentation follows the rules of UAX #29, except for the Prepend rule, which doesn't apply to the scripts that
+// are found in source code.
+func graphemeLen(s string) int {
+	r, size := utf8.DecodeRuneInString(s)
+	prev := graphemeBreak(r)
+	pictographic := prev == gbPictographic // Inside an emoji sequence (GB11).
+	regional := 0                          // Number of regional indicators seen (GB12 & GB13).
+
+	if prev == gbRegionalIndicator {
+		regional = 1
+	}
+
+	for size < len(s) {
+		r, width := utf8.DecodeRuneInString(s[size:])
+		next := graphemeBreak(r)
+
+		if !joinsGrapheme(prev, next, pictographic, regional) {
+			break
+		}
+
+		switch {
+		case next == gbRegionalIndicator:
+			regional++
+
+		case next == gbPictographic:
+			pictographic = true
+
+		case next != gbExtend && next != gbZWJ:
+			pictographic = false
+		}
+
+		prev = next
+		size += width
+	}
+
+	return size
+}
+
+// Reports whether a character with the grapheme cluster break property next belongs to the same cluster as the
+// preceding character with the property prev.
+func joinsGrapheme(prev, next int, pictographic bool, regional int) bool {
+	switch {
+	case prev == gbCR:
+		return next == gbLF // GB3 & GB4.
+
+	case prev == gbLF || prev == gbControl || next == gbCR || next == gbLF || next == gbControl:
+		return false // GB4 & GB5.
+
+	case prev == gbL:
+		return next == gbL || next == gbV || next == gbLV || next == gbLVT || next == gbExtend || next == gbZWJ ||
+			next == gbSpacingMark // GB6.
+
+	case (prev == gbLV || prev == gbV) && (next == gbV || next == gbT):
+		return true // GB7.
+
+	case (prev == gbLVT || prev == gbT) && next == gbT:
+		return true // GB8.
+
+	case next == gbExtend || next == gbZWJ || next == gbSpacingMark:
+		return true // GB9 & GB9a.
+
+	case prev == gbZWJ && next == gbPictographic:
+		return pictographic // GB11.
+
+	case prev == gbRegionalIndicator && next == gbRegionalIndicator:
+		return regional%2 == 1 // GB12 & GB13.
+	}
+
+	return false // GB999.
+}
+
+// Returns the number of terminal cells that the grapheme cluster occupies.
+//
+// The width of a cluster is the width of its first character, as defined by its East Asian Width. Clusters that
+// form an emoji (a pair of regional indicators, or a pictograph followed by an emoji presentation selector) are always
+// 2 columns wide, and clusters that start with a control or zero-width character don't take up any space.
+func clusterWidth(cluster string) int {
+	r, size := utf8.DecodeRuneInString(cluster)
+
+	switch graphemeBreak(r) {
+	case gbCR, gbLF, gbControl, gbExtend, gbZWJ:
+		return 0
+
+	case gbRegionalIndicator:
+		if size < len(cluster) {
+			return 2
+		}
+
+	case gbPictographic:
+		if r, _ := utf8.DecodeRuneInString(cluster[size:]); r == 0xFE0F {
+			return 2
+		}
+	}
+
+	if unicode.Is(eastAsianWide, r) {
+		return 2
+	}
+
+	return 1
+}
@@ -0,0 +1,215 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2026 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify the implementation of the "text" package.
+package text_test
+
+import (
+	"testing"
+
+	"github.com/kdeconinck/lens/internal/pkg/assert"
+	"github.com/kdeconinck/lens/internal/pkg/text"
+)
+
+// UT: Verify that the display width of a string respects grapheme clusters and East Asian Width.
+func TestColumns_Width(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		sInput string
+		want   int
+	}{
+		"When the string is empty, it has no width.": {
+			sInput: "",
+			want:   0,
+		},
+		"When the string is ASCII, every character takes 1 column.": {
+			sInput: "public",
+			want:   6,
+		},
+		"When the string contains wide CJK characters, every character takes 2 columns.": {
+			sInput: "// 日本語",
+			want:   9,
+		},
+		"When the string contains fullwidth characters, every character takes 2 columns.": {
+			sInput: "ＡＢ",
+			want:   4,
+		},
+		"When the string contains combining marks, they're folded into their base character.": {
+			sInput: "e\u0301e\u0300",
+			want:   2,
+		},
+		"When the string contains an emoji, it takes 2 columns.": {
+			sInput: "A🚀C",
+			want:   4,
+		},
+		"When the string contains an emoji ZWJ sequence, the whole sequence takes 2 columns.": {
+			sInput: "\U0001F469\u200D\U0001F4BB",
+			want:   2,
+		},
+		"When the string contains an emoji with a skin tone modifier, the whole sequence takes 2 columns.": {
+			sInput: "\U0001F44D\U0001F3FD",
+			want:   2,
+		},
+		"When the string contains a text-style pictograph with an emoji presentation selector, it takes 2 columns.": {
+			sInput: "\u2764\uFE0F",
+			want:   2,
+		},
+		"When the string contains a flag, the pair of regional indicators takes 2 columns.": {
+			sInput: "\U0001F1E7\U0001F1EA\U0001F1EF\U0001F1F5",
+			want:   4,
+		},
+		"When the string contains a decomposed Hangul syllable, the jamo form a single wide character.": {
+			sInput: "\u1100\u1161\u11A8",
+			want:   2,
+		},
+		"When the string contains zero-width characters, they don't take up any space.": {
+			sInput: "A\u200BB\uFEFF",
+			want:   2,
+		},
+		"When the string contains a tab, it's expanded to the next tab stop.": {
+			sInput: "日\tA",
+			want:   5,
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			cols := text.Columns{Unit: text.Display, TabWidth: 4}
+
+			// Act.
+			got := cols.Width(tc.sInput)
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d\033[0m\n"+
+				"\033[31mActual:   %d\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Verify that display columns place positions on grapheme cluster boundaries.
+func TestInput_LocationOf_Display(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		contentInput string
+		offsetInput  int
+		want         text.Location
+	}{
+		"When the offset follows a wide character, the column skips 2 cells.": {
+			contentInput: "日本語",
+			offsetInput:  6,
+			want:         newLocation(1, 5),
+		},
+		"When the offset points at a combining mark, the column of its base character is returned.": {
+			contentInput: "ae\u0301b",
+			offsetInput:  2,
+			want:         newLocation(1, 2),
+		},
+		"When the offset follows a combining mark, the column after its base character is returned.": {
+			contentInput: "ae\u0301b",
+			offsetInput:  4,
+			want:         newLocation(1, 3),
+		},
+		"When the offset points inside an emoji ZWJ sequence, the column of the sequence is returned.": {
+			contentInput: "A\U0001F469\u200D\U0001F4BBB",
+			offsetInput:  8,
+			want:         newLocation(1, 2),
+		},
+		"When the offset follows an emoji ZWJ sequence, the column after the sequence is returned.": {
+			contentInput: "A\U0001F469\u200D\U0001F4BBB",
+			offsetInput:  12,
+			want:         newLocation(1, 4),
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			input := newInput(tc.contentInput)
+
+			// Act.
+			got := input.LocationOf(tc.offsetInput, text.Columns{Unit: text.Display})
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Verify that display columns are translated back into offsets on grapheme cluster boundaries.
+func TestInput_OffsetOf_Display(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		contentInput string
+		locInput     text.Location
+		want         int
+		wantErr      bool
+	}{
+		"When the column follows a wide character, the offset of the next character is returned.": {
+			contentInput: "日本語",
+			locInput:     newLocation(1, 3),
+			want:         3,
+		},
+		"When the column points at the second cell of a wide character, an error is returned.": {
+			contentInput: "日本語",
+			locInput:     newLocation(1, 2),
+			wantErr:      true,
+		},
+		"When the column follows a character with a combining mark, the offset after the mark is returned.": {
+			contentInput: "e\u0301b",
+			locInput:     newLocation(1, 2),
+			want:         3,
+		},
+	} {
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			input := newInput(tc.contentInput)
+
+			// Act.
+			got, err := input.OffsetOf(tc.locInput, text.Columns{Unit: text.Display})
+
+			// Assert.
+			assert.Equalf(t, err != nil, tc.wantErr, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: error = %t\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tcName, tc.wantErr, err)
+
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d\033[0m\n"+
+				"\033[31mActual:   %d\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}